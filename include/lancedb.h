@@ -30,6 +30,131 @@ typedef struct VersionInfo {
   char *METADATA_JSON;
 } VersionInfo;
 
+/**
+ * List the IDs of the fragments in the version the table handle sees,
+ * as a JSON array of integers. Caller owns ids_json and must free it
+ * with simple_lancedb_free_string.
+ */
+struct SimpleResult *simple_lancedb_table_fragment_ids(void *table_handle, char **ids_json);
+
+/**
+ * Open an updater over one fragment of the version the table handle
+ * sees, reading `columns_json` (a JSON array of column names). On
+ * success *updater_handle is set to a handle driven with
+ * simple_lancedb_fragment_updater_next/update/finish and released with
+ * simple_lancedb_fragment_updater_close. Calls on a single handle must
+ * not overlap.
+ */
+struct SimpleResult *simple_lancedb_table_fragment_updater_open(void *table_handle,
+                                                                uint64_t fragment_id,
+                                                                const char *columns_json,
+                                                                void **updater_handle);
+
+/**
+ * Read the next batch of input columns as an Arrow IPC file. Deleted
+ * rows are skipped. Each batch must be answered with
+ * simple_lancedb_fragment_updater_update before the next is read. At
+ * the end of the fragment *batch_ipc_data is set to NULL and
+ * *batch_ipc_len to 0. The buffer is freed with
+ * simple_lancedb_free_ipc_data.
+ */
+struct SimpleResult *simple_lancedb_fragment_updater_next(void *updater_handle,
+                                                          uint8_t **batch_ipc_data,
+                                                          size_t *batch_ipc_len);
+
+/**
+ * Hand back the computed columns for the batch last returned by
+ * simple_lancedb_fragment_updater_next. `new_columns_ipc` must hold
+ * exactly one row per input row, in the same order.
+ */
+struct SimpleResult *simple_lancedb_fragment_updater_update(void *updater_handle,
+                                                            const uint8_t *new_columns_ipc,
+                                                            size_t new_columns_len);
+
+/**
+ * Finish the new column files once every batch has been read and
+ * answered. Nothing is committed: the rewritten fragment is written to
+ * *fragment_json (free with simple_lancedb_free_string) for a later
+ * simple_lancedb_table_commit_merge_columns. A fragment with no live
+ * rows writes no files and is returned unchanged; its new columns read
+ * as null. The handle must still be closed.
+ */
+struct SimpleResult *simple_lancedb_fragment_updater_finish(void *updater_handle,
+                                                            char **fragment_json);
+
+/**
+ * Close a fragment updater handle. Files written for an unfinished
+ * fragment are left for cleanup to remove.
+ */
+struct SimpleResult *simple_lancedb_fragment_updater_close(void *updater_handle);
+
+/**
+ * Commit fragments finished by simple_lancedb_fragment_updater_finish
+ * as one new version that adds the columns of `output_schema_ipc`.
+ * `fragments_json` is a JSON array holding every fragment of
+ * `read_version`, each as returned by finish. The commit fails
+ * if the table has changed in a conflicting way since read_version,
+ * and is refused while the handle has a version checked out. The
+ * table handle is moved to the new latest version, which is written
+ * to *version_out.
+ */
+struct SimpleResult *simple_lancedb_table_commit_merge_columns(void *table_handle,
+                                                               uint64_t read_version,
+                                                               const char *fragments_json,
+                                                               const uint8_t *output_schema_ipc,
+                                                               size_t output_schema_len,
+                                                               uint64_t *version_out);
+
+/**
+ * Create a branch named `branch` starting at `from_version`, or at the
+ * version `from_tag` points to when `from_tag` is non-null. Exactly one
+ * of a non-zero `from_version` or a non-null `from_tag` must be given.
+ */
+struct SimpleResult *simple_lancedb_table_branch_create(void *table_handle,
+                                                        const char *branch,
+                                                        uint64_t from_version,
+                                                        const char *from_tag);
+
+/**
+ * List every branch of the table as a JSON object keyed by branch name.
+ * Each value carries parent_version, created_at (unix seconds),
+ * manifest_size and an optional parent_branch (absent for branches cut
+ * from main). Caller owns branches_json and must free it with
+ * simple_lancedb_free_string.
+ */
+struct SimpleResult *simple_lancedb_table_branch_list(void *table_handle, char **branches_json);
+
+/**
+ * Open a new table handle tracking the latest version of `branch`.
+ * Writes through the new handle commit to the branch. The handle is
+ * written to *branch_handle and must be released with
+ * simple_lancedb_table_close.
+ */
+struct SimpleResult *simple_lancedb_table_checkout_branch(void *table_handle,
+                                                          const char *branch,
+                                                          void **branch_handle);
+
+/**
+ * Delete a branch. Errors when the branch does not exist.
+ */
+struct SimpleResult *simple_lancedb_table_branch_delete(void *table_handle, const char *branch);
+
+/**
+ * Compute the changes between two snapshots of the same table.
+ * `to_handle` and `from_handle` must be independent table handles
+ * already checked out at the `to` and `from` versions; `from_version`
+ * is the numeric version of `from_handle`. Deleted row IDs are found
+ * up front; the changed rows themselves are read as the stream is
+ * consumed, and the stream does not borrow either handle. On success
+ * *stream_handle is set to a stream read with
+ * simple_lancedb_stream_next and released with
+ * simple_lancedb_stream_close.
+ */
+struct SimpleResult *simple_lancedb_table_changes(void *to_handle,
+                                                  void *from_handle,
+                                                  uint64_t from_version,
+                                                  void **stream_handle);
+
 /**
  * Connect to a LanceDB database (simple version)
  */
@@ -157,6 +282,42 @@ void simple_lancedb_result_free(struct SimpleResult *result);
  */
 void simple_lancedb_free_string(char *s);
 
+/**
+ * Write `data_ipc` (Arrow IPC file bytes) as new data files of the
+ * table at `uri` without committing them. `options_json` may be null
+ * or {"storage_options": {..}, "max_rows_per_file": n}. The table must
+ * already exist and the data must match its schema. The uncommitted
+ * fragments are written to *fragments_json as a JSON array (free with
+ * simple_lancedb_free_string) for simple_lancedb_table_commit_fragments.
+ */
+struct SimpleResult *simple_lancedb_write_fragments(const char *uri,
+                                                    const uint8_t *data_ipc,
+                                                    size_t data_len,
+                                                    const char *options_json,
+                                                    char **fragments_json);
+
+/**
+ * Commit fragments returned by simple_lancedb_write_fragments, from
+ * any number of writers, as one append to the latest version of the
+ * table. The append's read version is the oldest "read_version" among
+ * the fragments, so the commit fails if a conflicting operation (an
+ * overwrite, a schema change) landed after any worker started. Fragment
+ * IDs are assigned at commit. Refused while the handle has a version
+ * checked out; otherwise the handle is moved to the new latest
+ * version, which is written to *version_out.
+ */
+struct SimpleResult *simple_lancedb_table_commit_fragments(void *table_handle,
+                                                           const char *fragments_json,
+                                                           uint64_t *version_out);
+
+/**
+ * List the fragments of the version the table handle sees, in
+ * manifest order, as a JSON array of {id, num_rows, physical_rows,
+ * num_deleted_rows}. num_rows excludes deleted rows. Caller owns
+ * fragments_json and must free it with simple_lancedb_free_string.
+ */
+struct SimpleResult *simple_lancedb_table_fragments(void *table_handle, char **fragments_json);
+
 /**
  * Create an index on the specified columns
  */
@@ -238,6 +399,15 @@ struct SimpleResult *simple_lancedb_table_create_index_v2(void *table_handle,
  */
 struct SimpleResult *simple_lancedb_table_count_rows(void *table_handle, int64_t *count);
 
+/**
+ * Count the rows matching `filter`, a SQL predicate evaluated with
+ * lancedb's count_rows so scalar indexes are used where they apply.
+ * A null or empty filter counts every row.
+ */
+struct SimpleResult *simple_lancedb_table_count_rows_where(void *table_handle,
+                                                           const char *filter,
+                                                           int64_t *count);
+
 /**
  * Get table version (simple version)
  */
@@ -255,6 +425,34 @@ struct SimpleResult *simple_lancedb_table_schema_ipc(void *table_handle,
                                                      uint8_t **schema_ipc_data,
                                                      size_t *schema_ipc_len);
 
+/**
+ * Get the table schema as of `version` in Arrow IPC format, together
+ * with a JSON object mapping each top-level field name to its lance
+ * field ID. Field IDs survive renames, so callers can tell a renamed
+ * column from a dropped one. The IPC buffer is freed with
+ * simple_lancedb_free_ipc_data and field_ids_json with
+ * simple_lancedb_free_string.
+ */
+struct SimpleResult *simple_lancedb_table_schema_at_ipc(void *table_handle,
+                                                        uint64_t version,
+                                                        uint8_t **schema_ipc_data,
+                                                        size_t *schema_ipc_len,
+                                                        char **field_ids_json);
+
+/**
+ * Update the schema-level metadata of the table, or the metadata of
+ * the field at `field_path` (dot-separated for nested fields) when it
+ * is non-null. `update_json` is {"set": {k: v}, "remove": [k],
+ * "replace": bool}; keys in both set and remove are removed. The
+ * change is committed as a new version against the latest dataset,
+ * the table handle is moved to it, and its number is written to
+ * *version_out. Refused while the handle has a version checked out.
+ */
+struct SimpleResult *simple_lancedb_table_update_metadata(void *table_handle,
+                                                          const char *field_path,
+                                                          const char *update_json,
+                                                          uint64_t *version_out);
+
 /**
  * Free IPC schema data allocated by simple_lancedb_table_schema_ipc
  */
@@ -267,6 +465,19 @@ struct SimpleResult *simple_lancedb_table_select_query(void *table_handle,
                                                        const char *query_config_json,
                                                        char **result_json);
 
+/**
+ * Execute a select query and return results as JSON, taking the vector
+ * search query as an Arrow IPC file (one column, one batch) instead of
+ * the JSON `vector` float list. The column keeps its native element
+ * type — Float16, Float32, Float64, Int8, or packed-bit UInt8 for
+ * Hamming search. `vector_ipc_data` may be NULL for non-vector queries.
+ */
+struct SimpleResult *simple_lancedb_table_select_query_v2(void *table_handle,
+                                                          const char *query_config_json,
+                                                          const uint8_t *vector_ipc_data,
+                                                          size_t vector_ipc_len,
+                                                          char **result_json);
+
 /**
  * Execute a select query and return results as Arrow IPC binary data.
  */
@@ -275,6 +486,18 @@ struct SimpleResult *simple_lancedb_table_select_query_ipc(void *table_handle,
                                                            uint8_t **result_ipc_data,
                                                            size_t *result_ipc_len);
 
+/**
+ * Arrow IPC counterpart of simple_lancedb_table_select_query_v2: the
+ * query vector arrives as an Arrow IPC file and the results leave as
+ * one. `vector_ipc_data` may be NULL for non-vector queries.
+ */
+struct SimpleResult *simple_lancedb_table_select_query_ipc_v2(void *table_handle,
+                                                              const char *query_config_json,
+                                                              const uint8_t *vector_ipc_data,
+                                                              size_t vector_ipc_len,
+                                                              uint8_t **result_ipc_data,
+                                                              size_t *result_ipc_len);
+
 /**
  * List every version reachable from the dataset. Returns a JSON array
  * of {version, timestamp, metadata} objects ordered as reported by the
@@ -283,6 +506,17 @@ struct SimpleResult *simple_lancedb_table_select_query_ipc(void *table_handle,
  */
 struct SimpleResult *simple_lancedb_table_list_versions(void *table_handle, char **versions_json);
 
+/**
+ * List versions newer than `since_version` from the latest manifest,
+ * including commits made by other writers since this handle was
+ * opened. Each entry is {version, timestamp, metadata, operation}.
+ * Caller owns events_json and must free it with
+ * simple_lancedb_free_string.
+ */
+struct SimpleResult *simple_lancedb_table_version_events(void *table_handle,
+                                                         uint64_t since_version,
+                                                         char **events_json);
+
 /**
  * Pin the table to a specific version. Subsequent reads see that
  * snapshot; writes are rejected until the table is brought back with
@@ -309,6 +543,27 @@ struct SimpleResult *simple_lancedb_table_checkout_latest(void *table_handle);
  */
 struct SimpleResult *simple_lancedb_table_restore(void *table_handle);
 
+/**
+ * Restore `version` (or the version `tag` points to, when `tag` is
+ * non-null) as a new latest version in a single call. The latest
+ * version is read first and written to *observed_version_out; when
+ * `expected_version` is non-zero and does not match, nothing is
+ * committed and an error is returned. The restore is then committed
+ * as a transaction read at that version. Lance never rejects a
+ * restore, so the committed version is checked instead: when it is
+ * not observed + 1, another writer committed in between and was
+ * superseded by the restore, and an error is returned with
+ * *observed_version_out set to the version the restore replaced. In
+ * both the success and the lost-race case the new version number is
+ * written to *new_version_out and the handle is left tracking latest.
+ */
+struct SimpleResult *simple_lancedb_table_restore_to(void *table_handle,
+                                                     uint64_t version,
+                                                     const char *tag,
+                                                     uint64_t expected_version,
+                                                     uint64_t *observed_version_out,
+                                                     uint64_t *new_version_out);
+
 /**
  * List every tag on the table as a JSON object keyed by tag name.
  * Each value carries the pinned version, manifest_size, and an
@@ -347,6 +602,53 @@ struct SimpleResult *simple_lancedb_table_tags_update(void *table_handle,
                                                       const char *tag,
                                                       uint64_t version);
 
+/**
+ * Delete the rows whose `_rowid` is listed in `row_ids_ipc` from the
+ * latest version of the table, in one commit. rows_deleted counts
+ * only rows that were live before the commit. On success *result_json
+ * is set to `{"rows_deleted": <u64>, "version": <u64>}` (free with
+ * simple_lancedb_free_string) and the handle moves to the new version.
+ * Refused while the handle has a version checked out.
+ */
+struct SimpleResult *simple_lancedb_table_delete_row_ids(void *table_handle,
+                                                         const uint8_t *row_ids_ipc,
+                                                         size_t row_ids_len,
+                                                         char **result_json);
+
+/**
+ * Update the rows whose `_rowid` is listed in `row_ids_ipc` using raw
+ * SQL expressions per column, in one commit against the latest
+ * version. `assignments_json` has the same shape as for
+ * simple_lancedb_table_update_expr. The IDs are resolved with
+ * take_rows, never turned into a filter. On success *result_json is
+ * set to `{"rows_updated": <u64>, "version": <u64>}` (free with
+ * simple_lancedb_free_string) and the handle moves to the new version.
+ * Refused while the handle has a version checked out.
+ */
+struct SimpleResult *simple_lancedb_table_update_row_ids(void *table_handle,
+                                                         const uint8_t *row_ids_ipc,
+                                                         size_t row_ids_len,
+                                                         const char *assignments_json,
+                                                         char **result_json);
+
+/**
+ * Draw up to `n` rows uniformly at random, without replacement, from
+ * the version the table handle sees and return them in storage order
+ * as an Arrow IPC file. Every row is returned when the population has
+ * at most `n` rows. `columns_json` is a JSON array of column names, or
+ * null for every column; `filter`, when not null, restricts the
+ * population to the matching rows. The same seed and version give the
+ * same sample. The IPC buffer is freed with
+ * simple_lancedb_free_ipc_data.
+ */
+struct SimpleResult *simple_lancedb_table_sample(void *table_handle,
+                                                 size_t n,
+                                                 uint64_t seed,
+                                                 const char *columns_json,
+                                                 const char *filter,
+                                                 uint8_t **result_ipc_data,
+                                                 size_t *result_ipc_len);
+
 /**
  * Add new columns to the table by evaluating SQL expressions over
  * existing rows. `transforms_json` is a JSON array of
@@ -363,6 +665,19 @@ struct SimpleResult *simple_lancedb_table_add_columns(void *table_handle,
                                                       const char *transforms_json,
                                                       uint64_t *version_out);
 
+/**
+ * Add all-null columns described by the fields of the Arrow IPC schema
+ * in `schema_ipc`. Only the manifest changes — no data files are
+ * written — so any Arrow type works, including FixedSizeList vectors
+ * and structs. Every field must be nullable.
+ *
+ * On success, the new commit version is written to *version_out.
+ */
+struct SimpleResult *simple_lancedb_table_add_null_columns(void *table_handle,
+                                                           const uint8_t *schema_ipc,
+                                                           size_t schema_len,
+                                                           uint64_t *version_out);
+
 /**
  * Alter existing columns — rename and/or change nullability.
  * `alterations_json` is a JSON array of {"path": "<col>", "rename":
@@ -370,9 +685,7 @@ struct SimpleResult *simple_lancedb_table_add_columns(void *table_handle,
  * optional and "unset" means "leave that attribute alone". Empty
  * arrays are rejected.
  *
- * data_type casts are intentionally not exposed in v1 — they require
- * Arrow DataType serialization and are slated for a follow-up. Callers
- * who need a cast can drop and re-add the column through add_columns.
+ * Casts go through simple_lancedb_table_alter_columns_v2.
  *
  * On success, the new commit version is written to *version_out.
  */
@@ -380,6 +693,20 @@ struct SimpleResult *simple_lancedb_table_alter_columns(void *table_handle,
                                                         const char *alterations_json,
                                                         uint64_t *version_out);
 
+/**
+ * Like simple_lancedb_table_alter_columns, with optional data_type
+ * casts. `casts_ipc` is an Arrow IPC file whose schema holds one field
+ * per column to cast, named by the alteration path and typed with the
+ * target type; it may be null when no column is cast. Casts rewrite
+ * the affected column's data files. The caller validates that each
+ * cast is supported before calling.
+ */
+struct SimpleResult *simple_lancedb_table_alter_columns_v2(void *table_handle,
+                                                          const char *alterations_json,
+                                                          const uint8_t *casts_ipc,
+                                                          size_t casts_len,
+                                                          uint64_t *version_out);
+
 /**
  * Drop columns from the table. `columns_json` is a JSON array of
  * strings naming the columns to remove. Empty arrays are rejected.
@@ -390,6 +717,47 @@ struct SimpleResult *simple_lancedb_table_drop_columns(void *table_handle,
                                                        const char *columns_json,
                                                        uint64_t *version_out);
 
+/**
+ * Plan and start a SQL query over the connection's tables. Table
+ * names resolve to the latest version of each table. Besides
+ * DataFusion's built-in functions, `l2_distance(a, b)` (squared),
+ * `cosine_distance(a, b)` and `dot_product(a, b)` are available for
+ * vector columns and list literals. On success *stream_handle is set
+ * to a stream read with simple_lancedb_stream_next and released with
+ * simple_lancedb_stream_close.
+ */
+struct SimpleResult *simple_lancedb_sql(void *handle, const char *sql, void **stream_handle);
+
+/**
+ * Compute storage statistics for the version the table handle sees.
+ * The result is a JSON object written to *stats_json and freed with
+ * simple_lancedb_free_string.
+ */
+struct SimpleResult *simple_lancedb_table_stats(void *table_handle, char **stats_json);
+
+/**
+ * Write the schema of the stream as an Arrow IPC file with no record
+ * batches. The buffer is freed with simple_lancedb_free_ipc_data.
+ */
+struct SimpleResult *simple_lancedb_stream_schema(void *stream_handle,
+                                                  uint8_t **schema_ipc_data,
+                                                  size_t *schema_ipc_len);
+
+/**
+ * Pull the next record batch from the stream as an Arrow IPC file.
+ * At the end of the stream *batch_ipc_data is set to NULL and
+ * *batch_ipc_len to 0. The buffer is freed with
+ * simple_lancedb_free_ipc_data.
+ */
+struct SimpleResult *simple_lancedb_stream_next(void *stream_handle,
+                                                uint8_t **batch_ipc_data,
+                                                size_t *batch_ipc_len);
+
+/**
+ * Close a stream handle, dropping any batches not yet read.
+ */
+struct SimpleResult *simple_lancedb_stream_close(void *stream_handle);
+
 /**
  * Create a table with a simple JSON schema
  */
@@ -405,6 +773,17 @@ struct SimpleResult *simple_lancedb_create_table_with_ipc(void *handle,
                                                           const uint8_t *schema_ipc,
                                                           size_t schema_len);
 
+/**
+ * Create a table with Arrow IPC schema and creation options.
+ * `options_json` is a JSON object such as `{"enable_stable_row_ids": true}`;
+ * null selects the defaults, matching simple_lancedb_create_table_with_ipc.
+ */
+struct SimpleResult *simple_lancedb_create_table_with_ipc_v2(void *handle,
+                                                             const char *table_name,
+                                                             const uint8_t *schema_ipc,
+                                                             size_t schema_len,
+                                                             const char *options_json);
+
 /**
  * Drop a table from the database (simple version)
  */
@@ -438,6 +817,34 @@ struct SimpleResult *simple_lancedb_table_optimize_v2(void *table_handle,
                                                       const char *action_json,
                                                       char **optimize_stats_json);
 
+/**
+ * Fetch rows by row ID (the `_rowid` column of a query with
+ * with_row_id) and return them as an Arrow IPC file, in the order of
+ * `row_ids`. `columns_json` is a JSON array of column names, or null
+ * for every column. The IPC buffer is freed with
+ * simple_lancedb_free_ipc_data.
+ */
+struct SimpleResult *simple_lancedb_table_take_row_ids(void *table_handle,
+                                                       const uint64_t *row_ids,
+                                                       size_t row_ids_len,
+                                                       const char *columns_json,
+                                                       uint8_t **result_ipc_data,
+                                                       size_t *result_ipc_len);
+
+/**
+ * Fetch rows by their zero-based offset in the version the table
+ * handle sees and return them as an Arrow IPC file, in the order of
+ * `offsets`. Deleted rows do not count towards offsets. `columns_json`
+ * is a JSON array of column names, or null for every column. The IPC
+ * buffer is freed with simple_lancedb_free_ipc_data.
+ */
+struct SimpleResult *simple_lancedb_table_take_offsets(void *table_handle,
+                                                       const uint64_t *offsets,
+                                                       size_t offsets_len,
+                                                       const char *columns_json,
+                                                       uint8_t **result_ipc_data,
+                                                       size_t *result_ipc_len);
+
 /**
  * Free a VersionInfo structure
  */
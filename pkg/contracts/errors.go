@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrReadOnlyTable is returned when a state-changing call is made on a
+// read-only table handle, such as one returned by ITableAsOf.AsOf.
+// Match it with errors.Is.
+var ErrReadOnlyTable = errors.New("table handle is read-only")
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed or
+// was issued for a different query. Match it with errors.Is.
+var ErrInvalidCursor = errors.New("invalid query cursor")
+
+// VersionPrunedError is returned when a timestamp lookup resolves to a
+// version that no longer exists because cleanup pruned it, or cannot
+// rule that out. Oldest and OldestTime describe the first version
+// retained after the pruned range; every version before it that was
+// committed after At is gone. Match it with errors.As.
+type VersionPrunedError struct {
+	At         time.Time
+	Oldest     uint64
+	OldestTime time.Time
+}
+
+func (e *VersionPrunedError) Error() string {
+	return fmt.Sprintf("no retained version live at %s: versions were pruned before version %d (%s)",
+		e.At.UTC().Format(time.RFC3339Nano), e.Oldest, e.OldestTime.UTC().Format(time.RFC3339Nano))
+}
+
+// VersionConflictError is returned when an operation guarded by an
+// expected current version finds the table at a different version.
+// Committed is zero when the operation was refused before anything
+// was written. When non-zero, the operation was committed as that
+// version on top of Actual, superseding the commits between Expected
+// and Actual. Match it with errors.As.
+type VersionConflictError struct {
+	Expected  uint64
+	Actual    uint64
+	Committed uint64
+}
+
+func (e *VersionConflictError) Error() string {
+	if e.Committed != 0 {
+		return fmt.Sprintf("version conflict: expected current version %d, committed as version %d on top of %d",
+			e.Expected, e.Committed, e.Actual)
+	}
+	return fmt.Sprintf("version conflict: expected current version %d, found %d", e.Expected, e.Actual)
+}
+
+// QueryTimeoutError is returned when a query runs past the limit set
+// with IQueryBuilder.Timeout or IVectorQueryBuilder.Timeout. The query
+// is cancelled in the backend. Match it with errors.As.
+type QueryTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *QueryTimeoutError) Error() string {
+	return fmt.Sprintf("query timed out after %s", e.Timeout)
+}
@@ -2,6 +2,8 @@ package contracts
 
 import (
 	"context"
+
+	"github.com/apache/arrow/go/v17/arrow/array"
 )
 
 type IConnection interface {
@@ -13,6 +15,62 @@ type IConnection interface {
 	IsClosed() bool
 }
 
+// IConnectionCreateTableOptions is an optional capability extension
+// layered on top of IConnection for creating tables with non-default
+// storage settings.
+//
+// Kept out of IConnection so adding the capability to a downstream
+// backend (or removing it later) is not a source-breaking change for
+// existing IConnection mocks/stubs. Callers detect the capability with
+// a type assertion:
+//
+//	if co, ok := conn.(contracts.IConnectionCreateTableOptions); ok {
+//	    table, err := co.CreateTableWithOptions(ctx, "events", schema,
+//	        contracts.CreateTableOptions{EnableStableRowIDs: true})
+//	}
+//
+// The shipped *internal.Connection implements this interface.
+type IConnectionCreateTableOptions interface {
+	// CreateTableWithOptions behaves like CreateTable but applies opts
+	// to the new dataset.
+	CreateTableWithOptions(ctx context.Context, name string, schema ISchema, opts CreateTableOptions) (ITable, error)
+}
+
+// IConnectionSQL is an optional capability extension layered on top
+// of IConnection for analytics that QueryConfig cannot express:
+// aggregates, GROUP BY, and joins between tables of the connection.
+// Table names in the query resolve to the latest version of each
+// table. Besides the standard SQL functions, l2_distance (squared),
+// cosine_distance and dot_product take a vector column and a vector
+// literal:
+//
+//	if sq, ok := conn.(contracts.IConnectionSQL); ok {
+//	    rr, err := sq.SQL(ctx, "SELECT label, count(*) FROM items GROUP BY label")
+//	    defer rr.Release()
+//	    for rr.Next() { ... }
+//	}
+//
+// Kept out of IConnection so adding the capability to a downstream
+// backend (or removing it later) is not a source-breaking change for
+// existing IConnection mocks/stubs.
+//
+// The shipped *internal.Connection implements this interface.
+type IConnectionSQL interface {
+	// SQL plans and starts query and streams the result. Records are
+	// produced as the reader advances; Release the reader to stop the
+	// query early. The reader stops with ctx.Err() once ctx is done.
+	SQL(ctx context.Context, query string) (array.RecordReader, error)
+}
+
+// CreateTableOptions configures a table at creation time. These
+// settings are fixed for the life of the dataset.
+type CreateTableOptions struct {
+	// EnableStableRowIDs gives every row an ID that survives updates
+	// and compaction, and turns on per-row version tracking. Required
+	// by ITableChanges.
+	EnableStableRowIDs bool `json:"enable_stable_row_ids"`
+}
+
 // ConnectionOptions holds options for establishing a database connection.
 type ConnectionOptions struct {
 	ReadConsistencyInterval *int
@@ -5,6 +5,7 @@ package contracts
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/arrow/go/v17/arrow"
 )
@@ -13,6 +14,10 @@ type IQueryBuilder interface {
 	Filter(condition string) IQueryBuilder
 	Limit(limit int) IQueryBuilder
 	Columns(columns []string) IQueryBuilder
+	// SelectExpr adds computed columns, evaluated by the backend, after
+	// any Columns. Once set, only Columns and these are returned; use
+	// ColumnExprsFromMap to pass a map.
+	SelectExpr(exprs ...ColumnExpr) IQueryBuilder
 	Offset(offset int) IQueryBuilder
 	// WithRowID adds the internal _rowid column to the result.
 	WithRowID() IQueryBuilder
@@ -24,8 +29,49 @@ type IQueryBuilder interface {
 	// search where vector and FTS scores need to be fused; on a single
 	// channel the backend may noop.
 	Rerank(cfg RerankerConfig) IQueryBuilder
+	// AsOfVersion runs the query against a read-only snapshot of the
+	// given version instead of the table's current state. The table
+	// handle itself is not checked out.
+	AsOfVersion(version uint64) IQueryBuilder
+	// Fragments restricts the scan to the given fragment IDs (see
+	// ITableFragments), so disjoint fragment sets can be scanned in
+	// parallel. Calling it with no IDs yields an empty record with the
+	// projected schema. Like OrderBy, it fails when combined with
+	// FastSearch, Postfilter or Rerank.
+	Fragments(ids ...uint64) IQueryBuilder
+	// OrderBy adds a sort key; keys apply in the order they are added.
+	// A single key on a numeric column with a BTree index, combined
+	// with Limit and no Filter or Fragments, is bounded through the
+	// index, so only the rows of the page and their ties are read and
+	// sorted. Any other sort reads every row that passes the filter (a
+	// top-k sort with a Limit), so its cost grows with the filtered row
+	// count rather than the page size. FastSearch, Postfilter and Rerank
+	// cannot be combined with it.
+	OrderBy(column string, desc, nullsFirst bool) IQueryBuilder
+	// Timeout bounds Execute, ExecuteAsync and ExecutePage in the
+	// backend, independently of ctx. A query still running when it
+	// elapses is cancelled and fails with *QueryTimeoutError. Zero or
+	// negative means no limit.
+	Timeout(d time.Duration) IQueryBuilder
 	Execute(ctx context.Context) (arrow.Record, error)
+	// Count returns the number of rows the query would return, honoring
+	// Filter, Offset, Limit and AsOfVersion, without materializing them.
+	// It cannot be combined with Fragments.
+	Count(ctx context.Context) (int64, error)
 	ExecuteAsync(ctx context.Context) (<-chan arrow.Record, <-chan error)
+	// After resumes the query after the page that returned cursor (see
+	// ExecutePage). An empty cursor starts from the first page.
+	After(cursor string) IQueryBuilder
+	// ExecutePage returns up to Limit rows in storage order and an
+	// opaque cursor for the next page, or "" once the scan is exhausted.
+	// The cursor names the fragment the page stopped in and how many of
+	// its matching rows were returned, so a page re-reads at most those
+	// rows of one fragment rather than every earlier page as Offset
+	// does, and no page is sorted. All pages read the version the first
+	// page saw. Offset, OrderBy, FastSearch, Postfilter and Rerank
+	// cannot be combined with it; a cursor from a different query fails
+	// with ErrInvalidCursor.
+	ExecutePage(ctx context.Context) (arrow.Record, string, error)
 	ApplyOptions(options *QueryOptions) IQueryBuilder
 }
 
@@ -33,6 +79,9 @@ type IVectorQueryBuilder interface {
 	Filter(condition string) IVectorQueryBuilder
 	Limit(limit int) IVectorQueryBuilder
 	Columns(columns []string) IVectorQueryBuilder
+	// SelectExpr adds computed columns after any Columns; _distance is
+	// still returned.
+	SelectExpr(exprs ...ColumnExpr) IVectorQueryBuilder
 	DistanceType(dt DistanceType) IVectorQueryBuilder
 	// Nprobes is the IVF partition scan count. 0 leaves the backend default.
 	Nprobes(n int) IVectorQueryBuilder
@@ -56,8 +105,30 @@ type IVectorQueryBuilder interface {
 	// `column` may be empty to let lancedb pick the one indexed FTS
 	// column on the table.
 	WithFullText(query, column string) IVectorQueryBuilder
+	// AsOfVersion runs the search against a read-only snapshot of the
+	// given version instead of the table's current state. The table
+	// handle itself is not checked out.
+	AsOfVersion(version uint64) IVectorQueryBuilder
+	// Timeout bounds Execute, ExecuteAsync and ExecutePage in the
+	// backend, independently of ctx. A search still running when it
+	// elapses is cancelled and fails with *QueryTimeoutError. Zero or
+	// negative means no limit.
+	Timeout(d time.Duration) IVectorQueryBuilder
 	Execute(ctx context.Context) (arrow.Record, error)
 	ExecuteAsync(ctx context.Context) (<-chan arrow.Record, <-chan error)
+	// After resumes the search after the page that returned cursor (see
+	// ExecutePage). An empty cursor starts from the nearest results.
+	After(cursor string) IVectorQueryBuilder
+	// ExecutePage returns the next Limit(k) nearest rows, ordered by
+	// distance and then row ID, and an opaque cursor for the next page,
+	// or "" once the search is exhausted. Each page resumes at the last
+	// distance seen, skipping the rows already returned at that
+	// distance, so earlier pages are not recomputed; all pages read the
+	// version the first page saw. A page fails once more than 1024 rows
+	// at one distance span pages; use a larger Limit for such data.
+	// Hybrid search, rerankers and Postfilter cannot be combined with
+	// it; a cursor from a different query fails with ErrInvalidCursor.
+	ExecutePage(ctx context.Context) (arrow.Record, string, error)
 	ApplyOptions(options *QueryOptions) IVectorQueryBuilder
 }
 
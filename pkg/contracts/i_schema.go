@@ -23,6 +23,11 @@ type ISchemaBuilder interface {
 	AddBinaryField(name string, nullable bool) ISchemaBuilder
 	AddBooleanField(name string, nullable bool) ISchemaBuilder
 	AddTimestampField(name string, unit arrow.TimeUnit, nullable bool) ISchemaBuilder
+	// WithMetadata merges metadata into the schema-level metadata.
+	WithMetadata(metadata map[string]string) ISchemaBuilder
+	// WithFieldMetadata merges metadata into the metadata of the
+	// already-added field name. Build fails if there is no such field.
+	WithFieldMetadata(name string, metadata map[string]string) ISchemaBuilder
 	Build() (ISchema, error)
 }
 
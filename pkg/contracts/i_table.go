@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/float16"
 )
 
 // ITable represents the interface for LanceDB table operations.
@@ -156,6 +158,50 @@ type ITableUpdateExpr interface {
 	UpdateExpr(ctx context.Context, filter string, assignments []UpdateAssignment) (*UpdateResult, error)
 }
 
+// ITableTypedVectorQuery is an optional capability extension layered on
+// top of ITable. ITable.VectorQuery is fixed to []float32; this
+// extension accepts the query in the vector column's native element
+// type, so float16, float64, int8-quantized and packed-bit binary
+// columns can be searched without a lossy detour through float32.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if tv, ok := table.(contracts.ITableTypedVectorQuery); ok {
+//	    rec, err := tv.VectorQueryBinary("fingerprint", bits).
+//	        DistanceType(contracts.DistanceTypeHamming).
+//	        Limit(10).
+//	        Execute(ctx)
+//	}
+//
+// Query vectors cross the FFI as Arrow arrays rather than JSON float
+// lists, so the element type reaches lancedb unchanged.
+//
+// The shipped *internal.Table implements this interface.
+type ITableTypedVectorQuery interface {
+	// VectorQueryFloat16 searches a FixedSizeList<Float16> column.
+	VectorQueryFloat16(column string, vector []float16.Num) IVectorQueryBuilder
+
+	// VectorQueryFloat64 searches a FixedSizeList<Float64> column.
+	VectorQueryFloat64(column string, vector []float64) IVectorQueryBuilder
+
+	// VectorQueryInt8 searches an int8-quantized FixedSizeList<Int8>
+	// column.
+	VectorQueryInt8(column string, vector []int8) IVectorQueryBuilder
+
+	// VectorQueryBinary searches a packed-bit FixedSizeList<Uint8>
+	// column; each byte carries eight dimensions. Pair it with
+	// DistanceTypeHamming.
+	VectorQueryBinary(column string, vector []uint8) IVectorQueryBuilder
+
+	// VectorQueryArrow accepts a prebuilt Float16, Float32, Float64,
+	// Int8 or Uint8 array. The array is not copied and must stay valid
+	// (not released) until Execute returns.
+	VectorQueryArrow(column string, vector arrow.Array) IVectorQueryBuilder
+}
+
 // AddDataOptions configures how data is added to a Table
 type AddDataOptions struct {
 	Mode WriteMode
@@ -242,6 +288,530 @@ type ITableTimeTravel interface {
 	TagUpdate(ctx context.Context, tag string, version uint64) error
 }
 
+// ITableAsOf is an optional capability extension layered on top of
+// ITable. Checkout / CheckoutTag move the pin of the shared handle, so
+// a goroutine reading history through them races every other user of
+// that handle. AsOf instead opens a second, independent handle pinned
+// to the requested snapshot and leaves the receiver untouched.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if ao, ok := table.(contracts.ITableAsOf); ok {
+//	    snap, err := ao.AsOf(ctx, contracts.AtVersion(3))
+//	    if err != nil { ... }
+//	    defer snap.Close()
+//	    n, err := snap.Count(ctx)
+//	}
+//
+// The returned handle is read-only: moving its pin (Checkout*,
+// Restore) or editing tags through it fails with ErrReadOnlyTable, and
+// data writes are rejected by lancedb because the handle is checked
+// out. The caller owns the handle and must Close it.
+//
+// For a single historical read, IQueryBuilder.AsOfVersion /
+// IVectorQueryBuilder.AsOfVersion open and close the pinned handle
+// around one Execute.
+//
+// The shipped *internal.Table implements this interface.
+type ITableAsOf interface {
+	// AsOf returns an independent read-only handle pinned to the
+	// version, tag or instant named by ref. Errors when ref is empty,
+	// sets more than one selector, or does not resolve.
+	AsOf(ctx context.Context, ref VersionRef) (ITable, error)
+}
+
+// ITableCheckoutAt is an optional capability extension layered on top
+// of ITable. It resolves a wall-clock instant to the latest version
+// committed at or before it, so callers asking "what did the table look
+// like at noon on March 3rd" don't have to scan ListVersions by hand.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if ca, ok := table.(contracts.ITableCheckoutAt); ok {
+//	    err := ca.CheckoutAt(ctx, time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC))
+//	}
+//
+// The read-only equivalent is ITableAsOf.AsOf with AtTime(ts).
+//
+// When the version live at the instant may have been pruned, the
+// lookup fails with *VersionPrunedError. That covers every retained
+// version being newer than the instant, and a pruned range right after
+// the newest version at or before it (for example a tagged version 1
+// kept while versions 2-4 were cleaned up). An instant before the
+// table was created fails with a plain error.
+//
+// The shipped *internal.Table implements this interface.
+type ITableCheckoutAt interface {
+	// ResolveVersionAt returns the latest version committed at or
+	// before ts without checking anything out.
+	ResolveVersionAt(ctx context.Context, ts time.Time) (uint64, error)
+
+	// CheckoutAt pins the table to ResolveVersionAt(ts). Same
+	// constraints as ITableTimeTravel.Checkout.
+	CheckoutAt(ctx context.Context, ts time.Time) error
+}
+
+// ITableChanges is an optional capability extension layered on top of
+// ITable. It exposes a change data feed: the rows inserted, updated and
+// deleted between two versions, so replicas can apply deltas instead of
+// re-reading the table after every write.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if ch, ok := table.(contracts.ITableChanges); ok {
+//	    rr, err := ch.Changes(ctx, lastSynced, current)
+//	    if err != nil { ... }
+//	    defer rr.Release()
+//	    for rr.Next() { apply(rr.Record()) }
+//	}
+//
+// The feed relies on stable row IDs, so the table must have been
+// created with CreateTableOptions.EnableStableRowIDs. Each output row
+// carries the table's columns (as of toVersion) followed by
+// ChangeRowIDColumn, ChangeTypeColumn and ChangeVersionColumn.
+// Deleted rows carry their values as of fromVersion and a null
+// ChangeVersionColumn, since lance does not record which version
+// removed a row. A row inserted and updated within the range is
+// reported once, as an insert. The reader streams inserts and updates
+// first, then deletes; only the changed rows are read, and deletes are
+// found from the manifests' deletion vectors rather than a scan.
+//
+// The shipped *internal.Table implements this interface.
+type ITableChanges interface {
+	// Changes returns the rows that differ between fromVersion and
+	// toVersion. fromVersion must be older than toVersion and both
+	// must still be retained.
+	Changes(ctx context.Context, fromVersion, toVersion uint64) (array.RecordReader, error)
+}
+
+// ITableBranches is an optional capability extension layered on top
+// of ITable. It exposes lance dataset branches: independent lines of
+// history cut from a version or tag of the parent, so experiments such
+// as re-embedding can be staged without touching main.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if br, ok := table.(contracts.ITableBranches); ok {
+//	    err := br.BranchCreate(ctx, "reembed", contracts.AtTag("prod"))
+//	    staged, err := br.CheckoutBranch(ctx, "reembed")
+//	    defer staged.Close()
+//	    err = staged.Add(ctx, rec, nil) // commits to "reembed" only
+//	}
+//
+// Unlike ITableTimeTravel.Checkout, CheckoutBranch does not move the
+// receiver: it returns a separate writable handle that tracks the
+// branch head. Version numbers on a branch continue from the version
+// it was cut at. Branch operations are rejected on read-only handles
+// with ErrReadOnlyTable.
+//
+// The shipped *internal.Table implements this interface.
+type ITableBranches interface {
+	// BranchCreate cuts a new branch named name from the version, tag
+	// or instant named by from. Errors when the branch already exists.
+	BranchCreate(ctx context.Context, name string, from VersionRef) error
+
+	// BranchList returns every branch on the table, keyed by name.
+	BranchList(ctx context.Context) (map[string]BranchInfo, error)
+
+	// CheckoutBranch opens a new handle on the head of the named
+	// branch. Writes through it commit to that branch. The caller owns
+	// the handle and must Close it.
+	CheckoutBranch(ctx context.Context, name string) (ITable, error)
+
+	// BranchDelete deletes a branch and its unshared data. Errors when
+	// the branch does not exist.
+	BranchDelete(ctx context.Context, name string) error
+}
+
+// ITableRestore is an optional capability extension layered on top of
+// ITable. ITableTimeTravel.Restore only promotes whatever the shared
+// handle has checked out, so a rollback via Checkout(v) + Restore()
+// races every other goroutine using that handle. RestoreVersion and
+// RestoreTag do the whole rollback in one call while holding the
+// handle exclusively.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if r, ok := table.(contracts.ITableRestore); ok {
+//	    v, err := r.RestoreVersion(ctx, 7, &contracts.RestoreOptions{ExpectedVersion: 12})
+//	}
+//
+// Both calls leave the handle tracking the latest version, dropping
+// any prior Checkout pin. With RestoreOptions.ExpectedVersion set, the
+// restore is refused with *VersionConflictError when another writer
+// has moved the table past the version the caller last saw. Lance
+// never rejects a restore commit, so a write that lands between the
+// check and the commit is detected afterwards: the restore is then
+// committed on top of that write, and *VersionConflictError reports
+// it with Committed set to the new version. Restoring Actual undoes
+// the rollback of the superseded write.
+//
+// The shipped *internal.Table implements this interface.
+type ITableRestore interface {
+	// RestoreVersion commits a new latest version whose contents equal
+	// version. Returns the new version number.
+	RestoreVersion(ctx context.Context, version uint64, opts *RestoreOptions) (uint64, error)
+
+	// RestoreTag commits a new latest version whose contents equal the
+	// version tag points to. Returns the new version number.
+	RestoreTag(ctx context.Context, tag string, opts *RestoreOptions) (uint64, error)
+}
+
+// ITableWatch is an optional capability extension layered on top of
+// ITable. It streams an event for every version committed to the
+// table, by this process or any other writer sharing the storage, so
+// caches and replicas can react to writes without polling by hand.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if w, ok := table.(contracts.ITableWatch); ok {
+//	    for ev := range w.Watch(ctx, time.Second) {
+//	        if ev.Err != nil { log.Print(ev.Err); continue }
+//	        log.Printf("v%d %s", ev.Version, ev.Operation)
+//	    }
+//	}
+//
+// Watch reads the dataset's version history every interval and emits
+// the versions committed since the previous poll, oldest first.
+// Versions that already exist when Watch is called are not emitted.
+// The channel is closed when ctx is done or the table is closed. A poll
+// that fails is reported as an event with only Err set and retried on
+// the next tick, so consumers must check Err. A consumer that stops
+// reading blocks the poller but never loses events.
+//
+// The shipped *internal.Table implements this interface.
+type ITableWatch interface {
+	// Watch polls for new versions every interval (one second when
+	// interval is not positive) until ctx is done.
+	Watch(ctx context.Context, interval time.Duration) <-chan VersionEvent
+}
+
+// ITableSchemaHistory is an optional capability extension layered on
+// top of ITable. It exposes the schema of any retained version and the
+// diff each version introduced, so AddColumns / AlterColumns /
+// DropColumns migrations can be audited and readers of old versions
+// know which columns exist.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if sh, ok := table.(contracts.ITableSchemaHistory); ok {
+//	    history, err := sh.SchemaHistory(ctx)
+//	    for _, v := range history {
+//	        if !v.Diff.IsEmpty() { log.Printf("v%d: %+v", v.Version, v.Diff) }
+//	    }
+//	}
+//
+// Diffs compare top-level fields by lance field ID, so a rename is
+// reported as a rename rather than a drop plus an add. A change inside
+// a struct or list field is reported as an alteration of its top-level
+// field.
+//
+// The shipped *internal.Table implements this interface.
+type ITableSchemaHistory interface {
+	// SchemaAt returns the table schema as of version.
+	SchemaAt(ctx context.Context, version uint64) (*arrow.Schema, error)
+
+	// SchemaHistory returns one entry per retained version, oldest
+	// first. The first entry's diff lists every column as added.
+	SchemaHistory(ctx context.Context) ([]SchemaVersion, error)
+}
+
+// ITableBackfill is an optional capability extension layered on top
+// of ITable. It adds columns whose values are computed by Go code —
+// embeddings, language detection, anything a SQL expression can't
+// express — without exporting the table and merge-inserting the
+// results by hand.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if bf, ok := table.(contracts.ITableBackfill); ok {
+//	    out := arrow.NewSchema([]arrow.Field{{Name: "lang", Type: arrow.BinaryTypes.String, Nullable: true}}, nil)
+//	    v, err := bf.AddColumnsFromFuncWithOptions(ctx, out, []string{"text"}, detectLanguage,
+//	        &contracts.AddColumnsFromFuncOptions{CheckpointPath: "/var/tmp/lang.ckpt"})
+//	}
+//
+// Fragments are processed one at a time: the read columns of each
+// fragment are streamed through fn, and the results are written as new
+// column files beside the fragment's existing data. Nothing is visible
+// until every fragment is done and a single version adding the columns
+// is committed. With a checkpoint, each finished fragment is recorded
+// so a crashed backfill resumes where it stopped; the checkpoint is
+// discarded if the table has moved on since it was written. A commit
+// by another writer in the meantime fails the backfill rather than
+// silently dropping rows.
+//
+// The shipped *internal.Table implements this interface.
+type ITableBackfill interface {
+	// AddColumnsFromFunc adds the columns of outputSchema, computing
+	// them with fn over readColumns. Returns the new commit version.
+	AddColumnsFromFunc(ctx context.Context, outputSchema *arrow.Schema, readColumns []string, fn ColumnFunc) (uint64, error)
+
+	// AddColumnsFromFuncWithOptions is AddColumnsFromFunc with
+	// checkpointing. A nil opts behaves like AddColumnsFromFunc.
+	AddColumnsFromFuncWithOptions(ctx context.Context, outputSchema *arrow.Schema, readColumns []string, fn ColumnFunc, opts *AddColumnsFromFuncOptions) (uint64, error)
+}
+
+// ITableNullColumns is an optional capability extension layered on top
+// of ITable. It adds columns that are null in every existing row
+// without rewriting any data files, for any Arrow type — including
+// FixedSizeList vectors and structs, which a CAST(NULL AS ...) SQL
+// expression cannot produce.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if nc, ok := table.(contracts.ITableNullColumns); ok {
+//	    v, err := nc.AddNullColumns(ctx, []arrow.Field{
+//	        {Name: "embedding", Type: arrow.FixedSizeListOf(768, arrow.PrimitiveTypes.Float32), Nullable: true},
+//	    })
+//	}
+//
+// The shipped *internal.Table implements this interface.
+type ITableNullColumns interface {
+	// AddNullColumns adds the given fields as all-null columns and
+	// returns the new commit version. Every field must be nullable and
+	// must not collide with an existing column.
+	AddNullColumns(ctx context.Context, fields []arrow.Field) (uint64, error)
+}
+
+// ITableMetadata is an optional capability extension layered on top
+// of ITable. It reads and updates the key/value metadata attached to
+// the table's Arrow schema and to individual fields — for example the
+// embedding model that produced a vector column, or where the data
+// came from. Updates are committed as a new version and show up in
+// Schema(ctx) as arrow.Schema / arrow.Field metadata.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if md, ok := table.(contracts.ITableMetadata); ok {
+//	    v, err := md.UpdateFieldMetadata(ctx, "embedding", contracts.MetadataUpdate{
+//	        Set: map[string]string{"model": "all-MiniLM-L6-v2", "model_version": "2"},
+//	    })
+//	}
+//
+// The shipped *internal.Table implements this interface.
+type ITableMetadata interface {
+	// TableMetadata returns the schema-level metadata. The map is
+	// empty, not nil, when none is set.
+	TableMetadata(ctx context.Context) (map[string]string, error)
+
+	// FieldMetadata returns the metadata of the field at path
+	// (dot-separated for nested struct fields).
+	FieldMetadata(ctx context.Context, path string) (map[string]string, error)
+
+	// UpdateTableMetadata applies update to the schema-level metadata
+	// and returns the new commit version.
+	UpdateTableMetadata(ctx context.Context, update MetadataUpdate) (uint64, error)
+
+	// UpdateFieldMetadata applies update to the metadata of the field
+	// at path and returns the new commit version.
+	UpdateFieldMetadata(ctx context.Context, path string, update MetadataUpdate) (uint64, error)
+}
+
+// ITableStats is an optional capability extension layered on top of
+// ITable. It reports how the table is laid out on storage — bytes,
+// fragments, deletions, data files and index sizes — so maintenance
+// jobs can decide when compaction is worth running:
+//
+//	if ts, ok := table.(contracts.ITableStats); ok {
+//	    st, err := ts.Stats(ctx)
+//	    if err == nil && (st.NumFragments > 32 || st.NumDeletedRows > st.NumRows/10) {
+//	        _, err = table.OptimizeWithAction(ctx, contracts.OptimizeAction{Kind: contracts.OptimizeCompact})
+//	    }
+//	}
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs.
+//
+// The shipped *internal.Table implements this interface.
+type ITableStats interface {
+	// Stats computes storage statistics for the checked-out version.
+	// It reads every fragment's metadata and deletion file and lists
+	// the index directories, so its cost grows with the fragment count.
+	Stats(ctx context.Context) (*TableStats, error)
+}
+
+// ITableCommitFragments is an optional capability extension layered
+// on top of ITable. It is the coordinator half of a distributed write:
+// workers call lancedb.WriteFragments against the table's URI from any
+// process, ship the returned FragmentMetadata (it marshals to JSON) to
+// the coordinator, and the coordinator commits all of them at once.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs. Callers detect the capability with a type
+// assertion:
+//
+//	if cf, ok := table.(contracts.ITableCommitFragments); ok {
+//	    v, err := cf.CommitFragments(ctx, fromAllWorkers)
+//	}
+//
+// The shipped *internal.Table implements this interface.
+type ITableCommitFragments interface {
+	// CommitFragments appends fragments to the latest version of the
+	// table in a single commit and returns the new version. Either all
+	// of the fragments become visible or none do. The commit fails if
+	// an overwrite or schema change landed after the oldest fragment's
+	// ReadVersion.
+	CommitFragments(ctx context.Context, fragments []FragmentMetadata) (uint64, error)
+}
+
+// ITableFragments is an optional capability extension layered on top
+// of ITable. It lists the fragments of the checked-out version so a
+// large scan can be split across goroutines or processes, each running
+// Query().Fragments(ids...) with the same filter and projection:
+//
+//	if tf, ok := table.(contracts.ITableFragments); ok {
+//	    frags, err := tf.Fragments(ctx)
+//	    for _, f := range frags {
+//	        go export(table.Query().Fragments(f.ID).Filter("active").Execute(ctx))
+//	    }
+//	}
+//
+// Pin the version (Checkout or AsOfVersion) when workers run while
+// other writers commit; compaction renumbers fragments.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs.
+//
+// The shipped *internal.Table implements this interface.
+type ITableFragments interface {
+	// Fragments returns the fragments of the checked-out version in
+	// manifest order.
+	Fragments(ctx context.Context) ([]FragmentInfo, error)
+}
+
+// ITableTake is an optional capability extension layered on top of
+// ITable. It fetches rows by position instead of by predicate, which
+// makes two-stage retrieval cheap: search with WithRowID and a narrow
+// projection, then hydrate the heavy columns of the hits only:
+//
+//	if tt, ok := table.(contracts.ITableTake); ok {
+//	    rec, err := tt.TakeRowIDs(ctx, rowIDs, []string{"id", "body"})
+//	}
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs.
+//
+// The shipped *internal.Table implements this interface.
+type ITableTake interface {
+	// TakeRowIDs returns the rows with the given `_rowid` values, in
+	// the order of ids, projected to columns (all columns when
+	// columns is empty). Row IDs are only stable across compaction
+	// when the table was created with stable row IDs enabled.
+	TakeRowIDs(ctx context.Context, ids []uint64, columns []string) (arrow.Record, error)
+	// TakeOffsets returns the rows at the given zero-based offsets of
+	// the checked-out version, in the order of offsets. Deleted rows
+	// do not count towards offsets.
+	TakeOffsets(ctx context.Context, offsets []uint64) (arrow.Record, error)
+}
+
+// ITableRowIDs is an optional capability extension layered on top of
+// ITable. It mutates exactly the rows named by `_rowid` values from an
+// earlier WithRowID query; the IDs travel to the backend as an Arrow
+// array, never as a SQL IN list:
+//
+//	if r, ok := table.(contracts.ITableRowIDs); ok {
+//	    res, err := r.DeleteRowIDs(ctx, flagged)
+//	}
+//
+// Without stable row IDs a row's ID changes when compaction rewrites
+// its fragment, so use IDs read from the latest version promptly.
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs.
+//
+// The shipped *internal.Table implements this interface.
+type ITableRowIDs interface {
+	// DeleteRowIDs deletes the rows with the given row IDs in one
+	// commit by marking them in the fragments' deletion vectors; no
+	// scan is involved. Duplicate IDs are ignored; an empty ids is
+	// rejected.
+	DeleteRowIDs(ctx context.Context, ids []uint64) (*DeleteResult, error)
+	// UpdateExprRowIDs is UpdateExpr scoped to the rows with the given
+	// row IDs instead of a filter. Assignments follow the UpdateExpr
+	// rules; an empty ids is rejected.
+	UpdateExprRowIDs(ctx context.Context, ids []uint64, assignments []UpdateAssignment) (*UpdateResult, error)
+}
+
+// ITableCountWhere is an optional capability extension layered on top
+// of ITable. It counts the rows matching a filter without
+// materializing them, using scalar indexes where they apply:
+//
+//	if c, ok := table.(contracts.ITableCountWhere); ok {
+//	    n, err := c.CountWhere(ctx, "status = 'open'")
+//	}
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs.
+//
+// The shipped *internal.Table implements this interface.
+type ITableCountWhere interface {
+	// CountWhere returns the number of rows matching filter, a SQL
+	// predicate. An empty filter counts every row, like Count.
+	CountWhere(ctx context.Context, filter string) (int64, error)
+}
+
+// ITableSample is an optional capability extension layered on top of
+// ITable. It draws a seeded uniform random sample of rows, reading only
+// the sampled rows rather than scanning the table, e.g. to train IVF
+// partitions outside lancedb:
+//
+//	if s, ok := table.(contracts.ITableSample); ok {
+//	    rec, err := s.Sample(ctx, 10000, []string{"embedding"}, 42)
+//	}
+//
+// Kept out of ITable so adding the capability to a downstream backend
+// (or removing it later) is not a source-breaking change for existing
+// ITable mocks/stubs.
+//
+// The shipped *internal.Table implements this interface.
+type ITableSample interface {
+	// Sample returns up to n rows drawn uniformly without replacement,
+	// in storage order; every row when the table has at most n. Nil
+	// columns selects every column. The same seed and table version
+	// give the same sample.
+	Sample(ctx context.Context, n int, columns []string, seed uint64) (arrow.Record, error)
+	// SampleWhere is Sample over the rows matching filter, a SQL
+	// predicate. Finding the population reads the filter's columns
+	// (or its scalar index); only the sampled rows are read in full.
+	SampleWhere(ctx context.Context, filter string, n int, columns []string, seed uint64) (arrow.Record, error)
+}
+
 // ITableSchemaEvolve is an optional capability extension layered on
 // top of ITable. It exposes lancedb's schema-evolution surface — adding
 // derived columns, renaming columns, toggling nullability, and
@@ -262,14 +832,13 @@ type ITableTimeTravel interface {
 //
 // Scope (v1):
 //   - AddColumns supports only the SqlExpressions transform
-//     (lance::dataset::NewColumnTransform::SqlExpressions). The
-//     BatchUDF / Stream / Reader / AllNulls variants are not exposed
-//     because they require closures or full Arrow IPC plumbing across
-//     the FFI boundary.
-//   - AlterColumns supports rename and nullable changes only. The
-//     data_type cast variant is deliberately deferred — it requires
-//     Arrow DataType serialization. Until then, callers needing a
-//     cast must AddColumns(<new-with-cast-expr>) → DropColumns(<old>).
+//     (lance::dataset::NewColumnTransform::SqlExpressions). All-null
+//     columns are added through ITableNullColumns and Go-computed
+//     columns through ITableBackfill.
+//   - AlterColumns supports rename, nullable changes and the casts
+//     listed on ColumnAlteration.DataType. A cast rewrites the
+//     column's data files; other casts are rejected before the FFI
+//     call.
 //   - DropColumns is the full surface.
 //
 // The shipped *internal.Table implements this interface.
@@ -280,8 +849,8 @@ type ITableSchemaEvolve interface {
 	// a no-op and almost always a caller bug.
 	AddColumns(ctx context.Context, transforms []NewColumnTransform) (uint64, error)
 
-	// AlterColumns renames columns and/or toggles their nullability.
-	// Returns the new commit version. Each entry must change at least
+	// AlterColumns renames, casts and/or toggles the nullability of
+	// columns. Returns the new commit version. Each entry must change at least
 	// one attribute; a no-op alteration is rejected. An empty
 	// alterations slice is rejected.
 	AlterColumns(ctx context.Context, alterations []ColumnAlteration) (uint64, error)
@@ -2,7 +2,10 @@ package contracts
 
 import (
 	"encoding/json"
+	"sort"
 	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
 )
 
 // IndexType represents the type of index to create
@@ -28,6 +31,7 @@ const (
 	DistanceTypeL2                              // Euclidean distance
 	DistanceTypeCosine                          // Cosine similarity
 	DistanceTypeDot                             // Dot product
+	DistanceTypeHamming                         // Hamming distance over packed-bit uint8 vectors
 )
 
 // IndexInfo represents information about an index on a table
@@ -186,6 +190,55 @@ type QueryConfig struct {
 	// default (no reranker on single-channel queries; automatic RRF on
 	// hybrid nearest_to + full_text_search queries).
 	Reranker *RerankerConfig `json:"reranker,omitempty"`
+
+	// FragmentIDs restricts a plain scan (no vector or FTS search) to
+	// the listed fragments, as returned by ITableFragments.Fragments.
+	// Nil scans every fragment; an empty, non-nil slice scans none and
+	// returns just the schema.
+	FragmentIDs []uint64 `json:"fragment_ids"`
+
+	// OrderBy sorts a plain scan (no vector or FTS search) by these
+	// keys, most significant first. Nil leaves rows in storage order.
+	// A single numeric key with a BTree index, a Limit and no Where or
+	// FragmentIDs is bounded through the index; any other sort reads
+	// every filtered row.
+	OrderBy []OrderByKey `json:"order_by,omitempty"`
+
+	// SelectExprs adds computed columns after Columns, in order. With
+	// SelectExprs set, the result holds only Columns and these; maps to
+	// lancedb's Select::Dynamic.
+	SelectExprs []ColumnExpr `json:"select_exprs,omitempty"`
+
+	// TimeoutMs bounds the query, from planning to the last result
+	// batch, in milliseconds. Nil or 0 means no limit.
+	TimeoutMs *uint64 `json:"timeout_ms,omitempty"`
+}
+
+// ColumnExpr is a computed result column: Expr is a SQL expression over
+// the table's columns, such as "lower(title)" or "score * 2", and Alias
+// names the result column.
+type ColumnExpr struct {
+	Alias string `json:"alias"`
+	Expr  string `json:"expr"`
+}
+
+// ColumnExprsFromMap converts an alias-to-expression map into
+// ColumnExprs ordered by alias, since map order is not stable.
+func ColumnExprsFromMap(exprs map[string]string) []ColumnExpr {
+	out := make([]ColumnExpr, 0, len(exprs))
+	for alias, expr := range exprs {
+		out = append(out, ColumnExpr{Alias: alias, Expr: expr})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Alias < out[j].Alias })
+	return out
+}
+
+// OrderByKey is one sort key of QueryConfig.OrderBy. Nulls sort last
+// unless NullsFirst is set, in either direction.
+type OrderByKey struct {
+	Column     string `json:"column"`
+	Descending bool   `json:"descending,omitempty"`
+	NullsFirst bool   `json:"nulls_first,omitempty"`
 }
 
 // VectorSearch represents vector similarity search parameters
@@ -195,6 +248,13 @@ type VectorSearch struct {
 	K            int       `json:"k"`
 	DistanceType *string   `json:"distance_type,omitempty"`
 
+	// QueryVector, when non-nil, replaces Vector and carries the query
+	// in the vector column's native element type: Float16, Float32,
+	// Float64, Int8, or packed-bit Uint8 (pair with "hamming"). It is
+	// shipped to the backend as an Arrow array rather than JSON and must
+	// stay valid (not released) until the query returns.
+	QueryVector arrow.Array `json:"-"`
+
 	// Nprobes is the IVF partition scan count. Larger => higher recall,
 	// higher latency. Maps to VectorQuery::nprobes().
 	Nprobes *int `json:"nprobes,omitempty"`
@@ -217,6 +277,11 @@ type VectorSearch struct {
 	// FullTextColumn optionally pins the FTS column. Empty lets lancedb
 	// pick the one FTS-indexed column on the table.
 	FullTextColumn string `json:"full_text_column,omitempty"`
+
+	// DistanceLowerBound drops candidates closer than this distance
+	// (inclusive bound). Set by cursor pagination to resume a search.
+	// Maps to VectorQuery::distance_range().
+	DistanceLowerBound *float32 `json:"distance_lower_bound,omitempty"`
 }
 
 // FTSSearch represents full-text search parameters
@@ -329,6 +394,13 @@ type UpdateResult struct {
 	Version     uint64 `json:"version"`
 }
 
+// DeleteResult reports the outcome of ITableRowIDs.DeleteRowIDs.
+// RowsDeleted counts only rows that were live before the commit.
+type DeleteResult struct {
+	RowsDeleted uint64 `json:"rows_deleted"`
+	Version     uint64 `json:"version"`
+}
+
 // VersionInfo describes one entry in the dataset version history. The
 // Timestamp field is unmarshaled from the backend's RFC3339 string
 // (UTC).
@@ -348,6 +420,282 @@ type TagInfo struct {
 	Branch       string `json:"branch,omitempty"`
 }
 
+// RestoreOptions configures ITableRestore calls. A nil *RestoreOptions
+// restores unconditionally.
+type RestoreOptions struct {
+	// ExpectedVersion, when non-zero, must equal the table's latest
+	// version at restore time; otherwise the restore is refused with
+	// *VersionConflictError. A write that lands while the restore
+	// commits is reported the same way, with Committed set (see
+	// ITableRestore).
+	ExpectedVersion uint64
+}
+
+// BranchInfo describes one branch entry. ParentBranch is empty for
+// branches cut from main. ParentVersion is the version the branch was
+// cut at. ManifestSize is the byte size of the branch's manifest.
+type BranchInfo struct {
+	ParentBranch  string    `json:"parent_branch,omitempty"`
+	ParentVersion uint64    `json:"parent_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	ManifestSize  uint64    `json:"manifest_size"`
+}
+
+// VersionRef names a historical snapshot of a table by version number,
+// by tag, or by wall-clock time. Exactly one of Version (non-zero), Tag
+// (non-empty) or Time (non-zero) must be set. Build one with AtVersion,
+// AtTag or AtTime.
+//
+// A Time ref resolves to the latest version committed at or before
+// that instant; see ITableCheckoutAt.
+type VersionRef struct {
+	Version uint64
+	Tag     string
+	Time    time.Time
+}
+
+// AtVersion returns a VersionRef for the given dataset version.
+func AtVersion(version uint64) VersionRef { return VersionRef{Version: version} }
+
+// AtTag returns a VersionRef for the version the given tag points at.
+func AtTag(tag string) VersionRef { return VersionRef{Tag: tag} }
+
+// AtTime returns a VersionRef for the latest version committed at or
+// before ts.
+func AtTime(ts time.Time) VersionRef { return VersionRef{Time: ts} }
+
+// Columns appended to every row of an ITableChanges feed.
+const (
+	ChangeRowIDColumn   = "_rowid"          // uint64 stable row ID
+	ChangeTypeColumn    = "_change_type"    // one of the ChangeType* values
+	ChangeVersionColumn = "_change_version" // uint64 version of the change; null for deletes
+)
+
+// Values of ChangeTypeColumn.
+const (
+	ChangeTypeInsert = "insert"
+	ChangeTypeUpdate = "update"
+	ChangeTypeDelete = "delete"
+)
+
+// VersionOperation classifies the commit that produced a version.
+type VersionOperation string
+
+// Values of VersionEvent.Operation. The kind is inferred from the
+// lance operation recorded in the commit, which does not always name
+// the API call that made it: VersionOperationMerge is a MergeInsert
+// commit that rewrote only the changed columns, while lance records a
+// MergeInsert that rewrote whole rows (the usual case) exactly like an
+// Update, so that case is reported as VersionOperationUpdate.
+// VersionOperationAddColumns is a schema evolution that added columns
+// computed from expressions or data.
+const (
+	VersionOperationAppend     VersionOperation = "append"
+	VersionOperationDelete     VersionOperation = "delete"
+	VersionOperationUpdate     VersionOperation = "update"
+	VersionOperationMerge      VersionOperation = "merge"
+	VersionOperationAddColumns VersionOperation = "add_columns"
+	VersionOperationIndex      VersionOperation = "index"
+	VersionOperationCompaction VersionOperation = "compaction"
+	VersionOperationOverwrite  VersionOperation = "overwrite"
+	VersionOperationRestore    VersionOperation = "restore"
+	VersionOperationSchema     VersionOperation = "schema"
+	VersionOperationOther      VersionOperation = "other"
+)
+
+// VersionEvent reports one newly committed version to an ITableWatch
+// consumer. Timestamp and Metadata match the VersionInfo entry for the
+// same version. Operation is a best-effort classification (see the
+// VersionOperation values), not a record of the call that committed.
+// When Err is set the event reports a failed poll instead and its
+// other fields are zero.
+type VersionEvent struct {
+	Version   uint64            `json:"version"`
+	Timestamp time.Time         `json:"timestamp"`
+	Metadata  map[string]string `json:"metadata"`
+	Operation VersionOperation  `json:"operation"`
+	Err       error             `json:"-"`
+}
+
+// SchemaVersion is one entry of ITableSchemaHistory.SchemaHistory: a
+// version, the schema it had, and the diff from the previous retained
+// version.
+type SchemaVersion struct {
+	VersionInfo
+	Schema *arrow.Schema
+	Diff   SchemaDiff
+}
+
+// SchemaDiff lists the top-level field changes between two schemas.
+type SchemaDiff struct {
+	Added   []arrow.Field
+	Dropped []arrow.Field
+	Renamed []FieldRename
+	// Altered holds fields whose type or nullability changed; a field
+	// that was also renamed appears in both Renamed and Altered.
+	Altered []FieldAlteration
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d SchemaDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Dropped) == 0 && len(d.Renamed) == 0 && len(d.Altered) == 0
+}
+
+// FieldRename records a column rename.
+type FieldRename struct {
+	From string
+	To   string
+}
+
+// FieldAlteration records a column whose type or nullability changed.
+type FieldAlteration struct {
+	Before arrow.Field
+	After  arrow.Field
+}
+
+// ColumnFunc computes new columns for ITableBackfill. It receives a
+// batch holding the requested read columns and must return a record
+// matching the output schema with exactly one row per input row, in
+// the same order. The input is only valid during the call; the
+// returned record is released by the caller.
+type ColumnFunc func(batch arrow.Record) (arrow.Record, error)
+
+// AddColumnsFromFuncOptions configures ITableBackfill calls.
+type AddColumnsFromFuncOptions struct {
+	// CheckpointPath, when set, is a local file recording finished
+	// fragments. Rerunning the same backfill with the same path skips
+	// them. The file is removed after a successful commit.
+	CheckpointPath string
+}
+
+// MetadataUpdate describes a change to a schema or field metadata
+// map. Set entries are written over the current map (or over an empty
+// one with Replace), then Remove keys are deleted; a key in both Set
+// and Remove ends up removed.
+type MetadataUpdate struct {
+	Set     map[string]string `json:"set,omitempty"`
+	Remove  []string          `json:"remove,omitempty"`
+	Replace bool              `json:"replace,omitempty"`
+}
+
+// TableStats describes the storage layout of one table version. Row
+// counts exclude deleted rows unless stated otherwise.
+type TableStats struct {
+	Version                   uint64           `json:"version"`
+	TotalBytes                uint64           `json:"total_bytes"`
+	NumRows                   uint64           `json:"num_rows"`
+	NumDeletedRows            uint64           `json:"num_deleted_rows"`
+	NumFragments              uint64           `json:"num_fragments"`
+	NumFragmentsWithDeletions uint64           `json:"num_fragments_with_deletions"`
+	RowsPerFragment           FragmentRowStats `json:"rows_per_fragment"`
+	NumDataFiles              uint64           `json:"num_data_files"`
+	NumDeletionFiles          uint64           `json:"num_deletion_files"`
+	Indices                   []IndexSizeStats `json:"indices"`
+}
+
+// FragmentRowStats summarizes live rows per fragment. All fields are
+// zero for a table without fragments.
+type FragmentRowStats struct {
+	Min uint64 `json:"min"`
+	Max uint64 `json:"max"`
+	P50 uint64 `json:"p50"`
+}
+
+// IndexSizeStats is the on-disk footprint of one named index. An
+// index gains a segment for each incremental update that has not been
+// merged by optimization yet.
+type IndexSizeStats struct {
+	Name        string `json:"name"`
+	NumSegments uint64 `json:"num_segments"`
+	SizeBytes   uint64 `json:"size_bytes"`
+}
+
+// FragmentMetadata is a fragment whose data files have been written
+// by WriteFragments but which is not part of any table version yet.
+// It marshals to the lance manifest entry as JSON, so workers can hand
+// their fragments to a coordinator with encoding/json; the coordinator
+// commits them with ITableCommitFragments. Each entry also records the
+// table version it was written against (see ReadVersion). Fragment IDs
+// are assigned at commit.
+type FragmentMetadata struct {
+	raw json.RawMessage
+}
+
+// MarshalJSON returns the lance manifest entry.
+func (f FragmentMetadata) MarshalJSON() ([]byte, error) {
+	if len(f.raw) == 0 {
+		return []byte("null"), nil
+	}
+	return f.raw, nil
+}
+
+// UnmarshalJSON accepts a manifest entry produced by MarshalJSON.
+func (f *FragmentMetadata) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Files []json.RawMessage `json:"files"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	f.raw = append(f.raw[:0], data...)
+	return nil
+}
+
+// NumRows returns the number of rows written to the fragment.
+func (f FragmentMetadata) NumRows() uint64 {
+	var v struct {
+		PhysicalRows uint64 `json:"physical_rows"`
+	}
+	_ = json.Unmarshal(f.raw, &v)
+	return v.PhysicalRows
+}
+
+// ReadVersion returns the table version the fragment was written
+// against.
+func (f FragmentMetadata) ReadVersion() uint64 {
+	var v struct {
+		ReadVersion uint64 `json:"read_version"`
+	}
+	_ = json.Unmarshal(f.raw, &v)
+	return v.ReadVersion
+}
+
+// DataFiles returns the paths of the fragment's data files, relative
+// to the table's data directory.
+func (f FragmentMetadata) DataFiles() []string {
+	var v struct {
+		Files []struct {
+			Path string `json:"path"`
+		} `json:"files"`
+	}
+	_ = json.Unmarshal(f.raw, &v)
+	paths := make([]string, 0, len(v.Files))
+	for _, file := range v.Files {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
+
+// WriteFragmentsOptions configures WriteFragments.
+type WriteFragmentsOptions struct {
+	// StorageOptions are passed to the object store, as in
+	// ConnectionOptions.StorageOptions.
+	StorageOptions map[string]string
+
+	// MaxRowsPerFile caps the rows per data file (and so per
+	// fragment). Zero uses the lance default of 1Mi rows.
+	MaxRowsPerFile int
+}
+
+// FragmentInfo describes one fragment of a table version. NumRows
+// excludes deleted rows; PhysicalRows includes them.
+type FragmentInfo struct {
+	ID             uint64 `json:"id"`
+	NumRows        uint64 `json:"num_rows"`
+	PhysicalRows   uint64 `json:"physical_rows"`
+	NumDeletedRows uint64 `json:"num_deleted_rows"`
+}
+
 // NewColumnTransform describes one new column to derive from existing
 // rows via a SQL expression. Mirrors the SqlExpressions variant of
 // lance::dataset::NewColumnTransform — the only variant exposed
@@ -362,16 +710,27 @@ type NewColumnTransform struct {
 }
 
 // ColumnAlteration describes one in-place change to an existing
-// column. Mirrors the rename + nullable subset of
-// lance::dataset::ColumnAlteration. The data_type cast variant is
-// deliberately not exposed in v1.
+// column. Mirrors lance::dataset::ColumnAlteration.
 //
 // Path is the existing column's name. Rename, when non-nil, sets a
 // new name. Nullable, when non-nil, toggles the column's nullability.
-// At least one of Rename or Nullable must be set per entry — an
-// alteration with neither is rejected as a caller bug.
+// DataType, when non-nil, casts the column to that type. At least one
+// of Rename, Nullable or DataType must be set per entry — an
+// alteration with none is rejected as a caller bug.
+//
+// Supported casts:
+//   - integer widening: a signed integer to a wider signed integer,
+//     or an unsigned integer to a wider unsigned or signed integer
+//     (e.g. int32 → int64, uint16 → int32)
+//   - between float16, float32 and float64, in either direction
+//   - between FixedSizeList vectors of float16 / float32 / float64
+//     with the same dimension (e.g. float32 → float16 embeddings)
+//
+// Any other cast, or a cast to the column's current type, is
+// rejected before the table is touched.
 type ColumnAlteration struct {
-	Path     string  `json:"path"`
-	Rename   *string `json:"rename,omitempty"`
-	Nullable *bool   `json:"nullable,omitempty"`
+	Path     string         `json:"path"`
+	Rename   *string        `json:"rename,omitempty"`
+	Nullable *bool          `json:"nullable,omitempty"`
+	DataType arrow.DataType `json:"-"`
 }
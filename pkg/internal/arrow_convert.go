@@ -109,3 +109,74 @@ func ipcBytesToRecord(ipcBytes []byte) (arrow.Record, error) {
 
 	return nil, fmt.Errorf("failed to concatenate record batches")
 }
+
+// ipcBytesToRecordReader deserializes an Arrow IPC file into a
+// RecordReader over its batches. The batches are retained by the reader,
+// so the IPC bytes need not outlive it. The caller must Release the
+// returned reader.
+func ipcBytesToRecordReader(ipcBytes []byte) (array.RecordReader, error) {
+	reader, err := ipc.NewFileReader(bytes.NewReader(ipcBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IPC reader: %w", err)
+	}
+	defer reader.Close()
+
+	records := make([]arrow.Record, 0, reader.NumRecords())
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+	for i := 0; i < reader.NumRecords(); i++ {
+		rec, err := reader.Record(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read record batch %d: %w", i, err)
+		}
+		rec.Retain()
+		records = append(records, rec)
+	}
+
+	rr, err := array.NewRecordReader(reader.Schema(), records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record reader: %w", err)
+	}
+	return rr, nil
+}
+
+// queryVectorToIPCBytes wraps a query vector in a single-column record and
+// serializes it to Arrow IPC so its element type reaches Rust intact.
+func queryVectorToIPCBytes(vector arrow.Array) ([]byte, error) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "vector", Type: vector.DataType(), Nullable: false}}, nil)
+	rec := array.NewRecord(schema, []arrow.Array{vector}, int64(vector.Len()))
+	defer rec.Release()
+	return recordsToIPCBytes([]arrow.Record{rec})
+}
+
+// ipcBytesToSchema reads the schema from an Arrow IPC file buffer.
+func ipcBytesToSchema(ipcBytes []byte) (*arrow.Schema, error) {
+	reader, err := ipc.NewFileReader(bytes.NewReader(ipcBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IPC reader: %w", err)
+	}
+	defer reader.Close()
+
+	schema := reader.Schema()
+	if schema == nil {
+		return nil, fmt.Errorf("failed to read schema from IPC data")
+	}
+	return schema, nil
+}
+
+// schemaToIPCBytes serializes a schema with no record batches into an
+// Arrow IPC file-format buffer.
+func schemaToIPCBytes(schema *arrow.Schema) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := ipc.NewFileWriter(&seekBuffer{&buf}, ipc.WithSchema(schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IPC writer: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close IPC writer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
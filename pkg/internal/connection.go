@@ -42,6 +42,7 @@ func NewConnection(handle unsafe.Pointer, closed bool) *Connection {
 }
 
 var _ contracts.IConnection = (*Connection)(nil)
+var _ contracts.IConnectionCreateTableOptions = (*Connection)(nil)
 
 // Close closes the connection to the database
 //
@@ -162,6 +163,20 @@ func (c *Connection) OpenTable(_ context.Context, name string) (contracts.ITable
 
 // CreateTable creates a new table in the database with context
 func (c *Connection) CreateTable(ctx context.Context, name string, schema contracts.ISchema) (contracts.ITable, error) {
+	return c.createTable(ctx, name, schema, nil)
+}
+
+// CreateTableWithOptions creates a new table with non-default creation
+// settings such as stable row IDs.
+func (c *Connection) CreateTableWithOptions(ctx context.Context, name string, schema contracts.ISchema,
+	opts contracts.CreateTableOptions) (contracts.ITable, error) {
+	return c.createTable(ctx, name, schema, &opts)
+}
+
+// createTable is the shared body of CreateTable and
+// CreateTableWithOptions. A nil opts keeps lancedb's defaults.
+func (c *Connection) createTable(ctx context.Context, name string, schema contracts.ISchema,
+	opts *contracts.CreateTableOptions) (contracts.ITable, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -179,6 +194,17 @@ func (c *Connection) CreateTable(ctx context.Context, name string, schema contra
 	// #nosec G103 - Required for freeing C allocated string memory
 	defer C.free(unsafe.Pointer(cName))
 
+	var cOptions *C.char
+	if opts != nil {
+		optionsJSON, err := json.Marshal(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal create options: %w", err)
+		}
+		cOptions = C.CString(string(optionsJSON))
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cOptions))
+	}
+
 	// Convert Go bytes to C pointers
 	var cSchemaPtr *C.uchar
 	if len(schemaIPC) > 0 {
@@ -186,11 +212,12 @@ func (c *Connection) CreateTable(ctx context.Context, name string, schema contra
 		cSchemaPtr = (*C.uchar)(unsafe.Pointer(&schemaIPC[0]))
 	}
 
-	result := C.simple_lancedb_create_table_with_ipc(
+	result := C.simple_lancedb_create_table_with_ipc_v2(
 		c.handle,
 		cName,
 		cSchemaPtr,
 		C.size_t(uintptr(len(schemaIPC))),
+		cOptions,
 	)
 	defer C.simple_lancedb_result_free(result)
 
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow/array"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+var _ contracts.IConnectionSQL = (*Connection)(nil)
+
+// SQL runs query with DataFusion over the connection's tables and
+// streams the result.
+func (c *Connection) SQL(ctx context.Context, query string) (array.RecordReader, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("sql: query is empty")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.closed || c.handle == nil {
+		return nil, fmt.Errorf("connection is closed")
+	}
+
+	cQuery := C.CString(query)
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cQuery))
+
+	// #nosec G103 - FFI handle for the result stream from C interop
+	var streamHandle unsafe.Pointer
+	result := C.simple_lancedb_sql(c.handle, cQuery, &streamHandle)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to execute SQL: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to execute SQL: unknown error")
+	}
+
+	return newRecordStream(ctx, streamHandle)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/apache/arrow/go/v17/arrow"
 
@@ -20,10 +21,21 @@ type QueryBuilder struct {
 	limit      int
 	offset     int
 	columns    []string
+	exprs      []lancedb.ColumnExpr
 	withRowID  bool
 	fastSearch bool
 	postfilter bool
 	reranker   *lancedb.RerankerConfig
+	// asOfVersion, when set, runs the query on a read-only snapshot
+	// opened just for this Execute.
+	asOfVersion *uint64
+	// fragmentIDs, when non-nil, restricts the scan to these fragments.
+	fragmentIDs []uint64
+	orderBy     []lancedb.OrderByKey
+	// cursor is the continuation token set by After.
+	cursor string
+	// timeout, when positive, is enforced by the backend.
+	timeout time.Duration
 }
 
 var _ lancedb.IQueryBuilder = (*QueryBuilder)(nil)
@@ -32,7 +44,7 @@ var _ lancedb.IVectorQueryBuilder = (*VectorQueryBuilder)(nil)
 // VectorQueryBuilder extends QueryBuilder for vector similarity searches
 type VectorQueryBuilder struct {
 	QueryBuilder
-	vector            []float32
+	vector            arrow.Array
 	column            string
 	limitSet          bool // tracks whether Limit() was explicitly called
 	distanceType      *lancedb.DistanceType
@@ -62,6 +74,12 @@ func (q *QueryBuilder) Columns(columns []string) lancedb.IQueryBuilder {
 	return q
 }
 
+// SelectExpr adds computed columns to the result
+func (q *QueryBuilder) SelectExpr(exprs ...lancedb.ColumnExpr) lancedb.IQueryBuilder {
+	q.exprs = append(q.exprs, exprs...)
+	return q
+}
+
 // Offset sets the number of rows to skip
 func (q *QueryBuilder) Offset(offset int) lancedb.IQueryBuilder {
 	q.offset = offset
@@ -93,17 +111,110 @@ func (q *QueryBuilder) Rerank(cfg lancedb.RerankerConfig) lancedb.IQueryBuilder
 	return q
 }
 
+// AsOfVersion runs the query against a read-only snapshot of version.
+func (q *QueryBuilder) AsOfVersion(version uint64) lancedb.IQueryBuilder {
+	v := version
+	q.asOfVersion = &v
+	return q
+}
+
+// Fragments restricts the scan to the given fragment IDs.
+func (q *QueryBuilder) Fragments(ids ...uint64) lancedb.IQueryBuilder {
+	q.fragmentIDs = append(make([]uint64, 0, len(ids)), ids...)
+	return q
+}
+
+// OrderBy appends a sort key to the query.
+func (q *QueryBuilder) OrderBy(column string, desc, nullsFirst bool) lancedb.IQueryBuilder {
+	q.orderBy = append(q.orderBy, lancedb.OrderByKey{Column: column, Descending: desc, NullsFirst: nullsFirst})
+	return q
+}
+
+// Timeout bounds the query's execution in the backend.
+func (q *QueryBuilder) Timeout(d time.Duration) lancedb.IQueryBuilder {
+	q.timeout = d
+	return q
+}
+
 // Execute executes the query and returns results.
 // Delegates to Table.SelectIPC() which holds the mutex and checks closed state.
 func (q *QueryBuilder) Execute(ctx context.Context) (arrow.Record, error) {
 	config := q.buildConfig()
-	ipcBytes, err := q.table.SelectIPC(ctx, config)
+	ipcBytes, err := q.selectIPC(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 	return ipcBytesToRecord(ipcBytes)
 }
 
+// Count counts the rows matching the query's filters with lancedb's
+// count_rows, then applies Offset and Limit arithmetically.
+func (q *QueryBuilder) Count(ctx context.Context) (int64, error) {
+	if q.fragmentIDs != nil {
+		return 0, fmt.Errorf("count: cannot be combined with Fragments")
+	}
+	filter := q.buildConfig().Where
+
+	var n int64
+	var err error
+	if q.asOfVersion == nil {
+		n, err = q.table.CountWhere(ctx, filter)
+	} else {
+		snapshot, serr := q.table.asOf(ctx, lancedb.AtVersion(*q.asOfVersion))
+		if serr != nil {
+			return 0, serr
+		}
+		defer snapshot.Close()
+		n, err = snapshot.CountWhere(ctx, filter)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if q.offset > 0 {
+		n -= int64(q.offset)
+		if n < 0 {
+			n = 0
+		}
+	}
+	if q.limit > 0 && n > int64(q.limit) {
+		n = int64(q.limit)
+	}
+	return n, nil
+}
+
+// selectIPC runs config against the builder's table, or against a
+// short-lived snapshot handle when AsOfVersion was set. The snapshot is
+// closed before returning; the IPC bytes are Go-owned so they outlive it.
+//
+//nolint:gocritic
+func (q *QueryBuilder) selectIPC(ctx context.Context, config lancedb.QueryConfig) ([]byte, error) {
+	if q.asOfVersion == nil {
+		ipcBytes, err := q.table.SelectIPC(ctx, config)
+		return ipcBytes, q.timeoutError(err)
+	}
+	snapshot, err := q.table.asOf(ctx, lancedb.AtVersion(*q.asOfVersion))
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Close()
+	ipcBytes, err := snapshot.SelectIPC(ctx, config)
+	return ipcBytes, q.timeoutError(err)
+}
+
+// queryTimeoutMarker is the text of QUERY_TIMEOUT_ERROR in
+// rust/src/query.rs, which reports a query that hit timeout_ms.
+const queryTimeoutMarker = "query timed out"
+
+// timeoutError turns the backend's timeout failure into a
+// *QueryTimeoutError and returns other errors unchanged.
+func (q *QueryBuilder) timeoutError(err error) error {
+	if err != nil && q.timeout > 0 && strings.Contains(err.Error(), queryTimeoutMarker) {
+		return &lancedb.QueryTimeoutError{Timeout: q.timeout}
+	}
+	return err
+}
+
 // executeAsync runs fn in a goroutine and routes its result or error to
 // the returned buffered channels. Exactly one channel receives a value;
 // both are always closed (via defer) so callers can safely use the
@@ -170,6 +281,14 @@ func (q *QueryBuilder) buildConfig() lancedb.QueryConfig {
 	if len(q.columns) > 0 {
 		config.Columns = q.columns
 	}
+	if len(q.exprs) > 0 {
+		config.SelectExprs = q.exprs
+	}
+	if q.timeout > 0 {
+		// Round up so a sub-millisecond timeout is not read as none.
+		ms := uint64((q.timeout + time.Millisecond - 1) / time.Millisecond)
+		config.TimeoutMs = &ms
+	}
 	config.WithRowID = q.withRowID
 	config.FastSearch = q.fastSearch
 	config.Postfilter = q.postfilter
@@ -177,6 +296,12 @@ func (q *QueryBuilder) buildConfig() lancedb.QueryConfig {
 		rc := *q.reranker
 		config.Reranker = &rc
 	}
+	if q.fragmentIDs != nil {
+		config.FragmentIDs = q.fragmentIDs
+	}
+	if len(q.orderBy) > 0 {
+		config.OrderBy = q.orderBy
+	}
 
 	return config
 }
@@ -200,6 +325,12 @@ func (vq *VectorQueryBuilder) Columns(columns []string) lancedb.IVectorQueryBuil
 	return vq
 }
 
+// SelectExpr adds computed columns to the result
+func (vq *VectorQueryBuilder) SelectExpr(exprs ...lancedb.ColumnExpr) lancedb.IVectorQueryBuilder {
+	vq.QueryBuilder.SelectExpr(exprs...)
+	return vq
+}
+
 // distanceTypeToString converts a DistanceType enum to the JSON string
 // expected by the Rust FFI. Returns an error for unknown values so an
 // out-of-range cast (e.g. lancedb.DistanceType(99)) surfaces as a normal
@@ -213,11 +344,35 @@ func distanceTypeToString(dt lancedb.DistanceType) (string, error) {
 		return "cosine", nil
 	case lancedb.DistanceTypeDot:
 		return "dot", nil
+	case lancedb.DistanceTypeHamming:
+		return "hamming", nil
 	default:
 		return "", fmt.Errorf("unknown DistanceType: %d", dt)
 	}
 }
 
+// validateQueryVector rejects query vectors lancedb cannot search with:
+// empty arrays, arrays containing nulls, and element types other than
+// Float16/Float32/Float64/Int8/Uint8. Hamming distance is only defined
+// over packed-bit Uint8 vectors, so that pairing is checked here too.
+func validateQueryVector(vector arrow.Array, dt *lancedb.DistanceType) error {
+	if vector == nil || vector.Len() == 0 {
+		return fmt.Errorf("vector search requires a non-empty query vector")
+	}
+	if vector.NullN() > 0 {
+		return fmt.Errorf("query vector must not contain nulls")
+	}
+	switch vector.DataType().ID() {
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64, arrow.INT8, arrow.UINT8:
+	default:
+		return fmt.Errorf("unsupported query vector element type: %s", vector.DataType())
+	}
+	if dt != nil && *dt == lancedb.DistanceTypeHamming && vector.DataType().ID() != arrow.UINT8 {
+		return fmt.Errorf("hamming distance requires a packed-bit uint8 query vector, got %s", vector.DataType())
+	}
+	return nil
+}
+
 // DistanceType sets the distance metric for vector similarity search
 func (vq *VectorQueryBuilder) DistanceType(dt lancedb.DistanceType) lancedb.IVectorQueryBuilder {
 	vq.distanceType = &dt
@@ -297,39 +452,65 @@ func (vq *VectorQueryBuilder) WithFullText(query, column string) lancedb.IVector
 	return vq
 }
 
+// AsOfVersion runs the search against a read-only snapshot of version.
+func (vq *VectorQueryBuilder) AsOfVersion(version uint64) lancedb.IVectorQueryBuilder {
+	vq.QueryBuilder.AsOfVersion(version)
+	return vq
+}
+
+// Timeout bounds the search's execution in the backend.
+func (vq *VectorQueryBuilder) Timeout(d time.Duration) lancedb.IVectorQueryBuilder {
+	vq.QueryBuilder.Timeout(d)
+	return vq
+}
+
 // Execute executes the vector search query and returns results.
 // Delegates to Table.SelectIPC() which holds the mutex and checks closed state.
 func (vq *VectorQueryBuilder) Execute(ctx context.Context) (arrow.Record, error) {
-	if len(vq.vector) == 0 {
-		return nil, fmt.Errorf("vector search requires a non-empty query vector")
+	config, err := vq.vectorConfig()
+	if err != nil {
+		return nil, err
+	}
+	ipcBytes, err := vq.selectIPC(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return ipcBytesToRecord(ipcBytes)
+}
+
+// vectorConfig validates the builder and converts it into a QueryConfig
+// with VectorSearch set.
+func (vq *VectorQueryBuilder) vectorConfig() (lancedb.QueryConfig, error) {
+	if err := validateQueryVector(vq.vector, vq.distanceType); err != nil {
+		return lancedb.QueryConfig{}, err
 	}
 	if vq.column == "" {
-		return nil, fmt.Errorf("vector search requires a non-empty column name")
+		return lancedb.QueryConfig{}, fmt.Errorf("vector search requires a non-empty column name")
 	}
 
 	k := vq.limit
 	if !vq.limitSet {
-		return nil, fmt.Errorf("vector search requires a positive K value: call .Limit(k) before .Execute()")
+		return lancedb.QueryConfig{}, fmt.Errorf("vector search requires a positive K value: call .Limit(k) before .Execute()")
 	}
 	if k <= 0 {
-		return nil, fmt.Errorf("K must be a positive integer, got %d", k)
+		return lancedb.QueryConfig{}, fmt.Errorf("K must be a positive integer, got %d", k)
 	}
 
 	if vq.offset != 0 {
-		return nil, fmt.Errorf("VectorQueryBuilder does not support Offset(); use QueryBuilder for offset-based pagination")
+		return lancedb.QueryConfig{}, fmt.Errorf("VectorQueryBuilder does not support Offset(); use After/ExecutePage for cursor-based pagination")
 	}
 
 	config := vq.buildConfig()
 	config.Limit = nil // K controls result count for vector search, not Limit
 	config.VectorSearch = &lancedb.VectorSearch{
-		Column: vq.column,
-		Vector: vq.vector,
-		K:      k,
+		Column:      vq.column,
+		QueryVector: vq.vector,
+		K:           k,
 	}
 	if vq.distanceType != nil && *vq.distanceType != lancedb.DistanceTypeUnspecified {
 		dt, err := distanceTypeToString(*vq.distanceType)
 		if err != nil {
-			return nil, err
+			return lancedb.QueryConfig{}, err
 		}
 		config.VectorSearch.DistanceType = &dt
 	}
@@ -339,12 +520,7 @@ func (vq *VectorQueryBuilder) Execute(ctx context.Context) (arrow.Record, error)
 	config.VectorSearch.BypassVectorIndex = vq.bypassVectorIndex
 	config.VectorSearch.FullTextQuery = vq.fullTextQuery
 	config.VectorSearch.FullTextColumn = vq.fullTextColumn
-
-	ipcBytes, err := vq.table.SelectIPC(ctx, config)
-	if err != nil {
-		return nil, err
-	}
-	return ipcBytesToRecord(ipcBytes)
+	return config, nil
 }
 
 // ExecuteAsync executes the vector query asynchronously
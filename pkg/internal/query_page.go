@@ -0,0 +1,449 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	lancedb "github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+const (
+	rowIDColumn    = "_rowid"
+	distanceColumn = "_distance"
+
+	// maxPageTies bounds the row IDs a vector search cursor carries for
+	// one distance, and with it the NOT IN clause the next page sends.
+	maxPageTies = 1024
+)
+
+// pageCursor is the state behind an ExecutePage continuation token.
+// Plain scans resume in fragment Fragment after skipping the first
+// Offset matching rows of it; vector searches resume at Distance,
+// skipping Ties, the row IDs already returned at exactly that distance.
+type pageCursor struct {
+	Version  uint64   `json:"v"`
+	Query    uint64   `json:"q"`
+	Fragment uint64   `json:"f,omitempty"`
+	Offset   int      `json:"o,omitempty"`
+	Distance float32  `json:"d,omitempty"`
+	Ties     []uint64 `json:"t,omitempty"`
+}
+
+func (c *pageCursor) encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodePageCursor(token string) (*pageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", lancedb.ErrInvalidCursor, err)
+	}
+	var c pageCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%w: %v", lancedb.ErrInvalidCursor, err)
+	}
+	return &c, nil
+}
+
+// queryFingerprint identifies the query a cursor belongs to. The page
+// size and timeout are left out so they may change between pages.
+//
+//nolint:gocritic
+func queryFingerprint(config lancedb.QueryConfig, vector arrow.Array) uint64 {
+	config.Limit = nil
+	config.TimeoutMs = nil
+	if config.VectorSearch != nil {
+		vs := *config.VectorSearch
+		vs.K = 0
+		config.VectorSearch = &vs
+	}
+	h := fnv.New64a()
+	b, _ := json.Marshal(config)
+	_, _ = h.Write(b)
+	if vector != nil {
+		_, _ = fmt.Fprint(h, vector)
+	}
+	return h.Sum64()
+}
+
+// After sets the cursor ExecutePage resumes from.
+func (q *QueryBuilder) After(cursor string) lancedb.IQueryBuilder {
+	q.cursor = cursor
+	return q
+}
+
+// ExecutePage returns the next page of the scan in storage order. The
+// scan walks the fragments of the pinned version (or those set with
+// Fragments) one at a time, and the cursor records the fragment the
+// page stopped in and how many of its matching rows were returned. The
+// next page starts at that fragment, so at most those rows of one
+// fragment are read again and skipped; nothing is sorted.
+func (q *QueryBuilder) ExecutePage(ctx context.Context) (arrow.Record, string, error) {
+	if q.offset > 0 {
+		return nil, "", fmt.Errorf("execute page: cannot be combined with Offset; pass the cursor to After")
+	}
+	if len(q.orderBy) > 0 {
+		return nil, "", fmt.Errorf("execute page: cannot be combined with OrderBy")
+	}
+
+	config := q.buildConfig()
+	fingerprint := queryFingerprint(config, nil)
+	cur, version, err := q.startPage(ctx, fingerprint)
+	if err != nil {
+		return nil, "", err
+	}
+	snapshot, err := q.table.asOf(ctx, lancedb.AtVersion(version))
+	if err != nil {
+		return nil, "", err
+	}
+	defer snapshot.Close()
+
+	fragments := config.FragmentIDs
+	if fragments == nil {
+		infos, err := snapshot.Fragments(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		fragments = make([]uint64, len(infos))
+		for i, f := range infos {
+			fragments[i] = f.ID
+		}
+	}
+	start, skip := 0, 0
+	if cur != nil {
+		for start < len(fragments) && fragments[start] != cur.Fragment {
+			start++
+		}
+		if start == len(fragments) {
+			return nil, "", fmt.Errorf("%w: fragment %d is not part of the scan", lancedb.ErrInvalidCursor, cur.Fragment)
+		}
+		skip = cur.Offset
+	}
+
+	var (
+		records []arrow.Record
+		rows    int
+		next    *pageCursor
+	)
+	defer func() {
+		for _, r := range records {
+			r.Release()
+		}
+	}()
+	for i := start; i < len(fragments) && next == nil; i++ {
+		fragmentConfig := config
+		fragmentConfig.FragmentIDs = fragments[i : i+1]
+		if skip > 0 {
+			offset := skip
+			fragmentConfig.Offset = &offset
+		}
+		if q.limit > 0 {
+			remaining := q.limit - rows
+			fragmentConfig.Limit = &remaining
+		}
+		rec, err := q.snapshotRecord(ctx, snapshot, fragmentConfig)
+		if err != nil {
+			return nil, "", err
+		}
+		n := 0
+		if rec != nil {
+			records = append(records, rec)
+			n = int(rec.NumRows())
+		}
+		rows += n
+		if q.limit > 0 && rows == q.limit {
+			next = &pageCursor{Version: version, Query: fingerprint, Fragment: fragments[i], Offset: skip + n}
+		}
+		skip = 0
+	}
+	if len(records) == 0 {
+		// No fragment left to read; an empty fragment list still yields
+		// the projected schema.
+		emptyConfig := config
+		emptyConfig.FragmentIDs = []uint64{}
+		rec, err := q.snapshotRecord(ctx, snapshot, emptyConfig)
+		if err != nil || rec == nil {
+			return nil, "", err
+		}
+		records = append(records, rec)
+	}
+
+	token := ""
+	if next != nil {
+		if token, err = next.encode(); err != nil {
+			return nil, "", err
+		}
+	}
+	return concatRecords(records), token, nil
+}
+
+// startPage resolves the cursor set by After. Without one it pins the
+// version the first page reads: AsOfVersion if set, else the table's
+// current version.
+func (q *QueryBuilder) startPage(ctx context.Context, fingerprint uint64) (*pageCursor, uint64, error) {
+	if q.cursor == "" {
+		if q.asOfVersion != nil {
+			return nil, *q.asOfVersion, nil
+		}
+		v, err := q.table.Version(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		return nil, uint64(v), nil
+	}
+	cur, err := decodePageCursor(q.cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	if cur.Query != fingerprint {
+		return nil, 0, fmt.Errorf("%w: issued for a different query", lancedb.ErrInvalidCursor)
+	}
+	if q.asOfVersion != nil && *q.asOfVersion != cur.Version {
+		return nil, 0, fmt.Errorf("%w: issued for version %d, not %d", lancedb.ErrInvalidCursor, cur.Version, *q.asOfVersion)
+	}
+	return cur, cur.Version, nil
+}
+
+// pageRecord runs config against a snapshot of version, so every page
+// of a cursor reads the same data.
+//
+//nolint:gocritic
+func (q *QueryBuilder) pageRecord(ctx context.Context, version uint64, config lancedb.QueryConfig) (arrow.Record, error) {
+	snapshot, err := q.table.asOf(ctx, lancedb.AtVersion(version))
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Close()
+	return q.snapshotRecord(ctx, snapshot, config)
+}
+
+// snapshotRecord runs config against an open snapshot.
+//
+//nolint:gocritic
+func (q *QueryBuilder) snapshotRecord(ctx context.Context, snapshot *Table, config lancedb.QueryConfig) (arrow.Record, error) {
+	ipcBytes, err := snapshot.SelectIPC(ctx, config)
+	if err != nil {
+		return nil, q.timeoutError(err)
+	}
+	return ipcBytesToRecord(ipcBytes)
+}
+
+// After sets the cursor ExecutePage resumes from.
+func (vq *VectorQueryBuilder) After(cursor string) lancedb.IVectorQueryBuilder {
+	vq.QueryBuilder.After(cursor)
+	return vq
+}
+
+// ExecutePage returns the next k nearest rows. The search resumes with a
+// distance lower bound at the last distance returned and excludes the
+// row IDs already returned at that distance, so ties straddling a page
+// boundary are returned exactly once.
+func (vq *VectorQueryBuilder) ExecutePage(ctx context.Context) (arrow.Record, string, error) {
+	config, err := vq.vectorConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	if config.VectorSearch.FullTextQuery != "" || config.Reranker != nil {
+		return nil, "", fmt.Errorf("execute page: hybrid search and rerankers have no resumable distance order")
+	}
+	if vq.postfilter {
+		return nil, "", fmt.Errorf("execute page: cannot be combined with Postfilter")
+	}
+
+	fingerprint := queryFingerprint(config, vq.vector)
+	cur, version, err := vq.startPage(ctx, fingerprint)
+	if err != nil {
+		return nil, "", err
+	}
+	if cur != nil {
+		lower := cur.Distance
+		config.VectorSearch.DistanceLowerBound = &lower
+		if len(cur.Ties) > 0 {
+			ids := make([]string, len(cur.Ties))
+			for i, id := range cur.Ties {
+				ids[i] = strconv.FormatUint(id, 10)
+			}
+			config.Where = andFilter(config.Where, fmt.Sprintf("%s NOT IN (%s)", rowIDColumn, strings.Join(ids, ", ")))
+		}
+	}
+	config.WithRowID = true
+
+	rec, err := vq.pageRecord(ctx, version, config)
+	if err != nil || rec == nil {
+		return nil, "", err
+	}
+	rec, err = sortByDistanceAndRowID(rec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if n := int(rec.NumRows()); n == vq.limit {
+		rowIDs, _ := pageRowIDs(rec)
+		distances := rec.Column(rec.Schema().FieldIndices(distanceColumn)[0]).(*array.Float32)
+		last := distances.Value(n - 1)
+		var ties []uint64
+		if cur != nil && cur.Distance == last {
+			ties = append(ties, cur.Ties...)
+		}
+		for i := n - 1; i >= 0 && distances.Value(i) == last; i-- {
+			ties = append(ties, rowIDs.Value(i))
+		}
+		if len(ties) > maxPageTies {
+			rec.Release()
+			return nil, "", fmt.Errorf("execute page: more than %d rows at distance %v span pages; use a larger Limit",
+				maxPageTies, last)
+		}
+		next, err = (&pageCursor{
+			Version:  version,
+			Query:    fingerprint,
+			Distance: last,
+			Ties:     ties,
+		}).encode()
+		if err != nil {
+			rec.Release()
+			return nil, "", err
+		}
+	}
+	if !vq.withRowID {
+		rec = dropColumn(rec, rowIDColumn)
+	}
+	return rec, next, nil
+}
+
+// andFilter appends clause to a WHERE expression.
+func andFilter(where, clause string) string {
+	if where == "" {
+		return clause
+	}
+	return "(" + where + ") AND " + clause
+}
+
+// pageRowIDs returns the _rowid column of a page.
+func pageRowIDs(rec arrow.Record) (*array.Uint64, error) {
+	idx := rec.Schema().FieldIndices(rowIDColumn)
+	if len(idx) != 1 {
+		return nil, fmt.Errorf("execute page: result has no %s column", rowIDColumn)
+	}
+	ids, ok := rec.Column(idx[0]).(*array.Uint64)
+	if !ok {
+		return nil, fmt.Errorf("execute page: %s column is %s, want uint64", rowIDColumn, rec.Column(idx[0]).DataType())
+	}
+	return ids, nil
+}
+
+// sortByDistanceAndRowID orders a vector search page by _distance and
+// then _rowid. lancedb already sorts by distance, so only the order of
+// ties can change; the record is returned as is when nothing moves.
+func sortByDistanceAndRowID(rec arrow.Record) (arrow.Record, error) {
+	rowIDs, err := pageRowIDs(rec)
+	if err != nil {
+		rec.Release()
+		return nil, err
+	}
+	idx := rec.Schema().FieldIndices(distanceColumn)
+	if len(idx) != 1 {
+		rec.Release()
+		return nil, fmt.Errorf("execute page: result has no %s column", distanceColumn)
+	}
+	distances, ok := rec.Column(idx[0]).(*array.Float32)
+	if !ok {
+		rec.Release()
+		return nil, fmt.Errorf("execute page: %s column is %s, want float32", distanceColumn, rec.Column(idx[0]).DataType())
+	}
+
+	less := func(a, b int) bool {
+		if da, db := distances.Value(a), distances.Value(b); da != db {
+			return da < db
+		}
+		return rowIDs.Value(a) < rowIDs.Value(b)
+	}
+	order := make([]int, rec.NumRows())
+	for i := range order {
+		order[i] = i
+	}
+	if sort.SliceIsSorted(order, func(i, j int) bool { return less(order[i], order[j]) }) {
+		return rec, nil
+	}
+	sort.SliceStable(order, func(i, j int) bool { return less(order[i], order[j]) })
+
+	cols := make([]arrow.Array, rec.NumCols())
+	defer func() {
+		for _, c := range cols {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+	for c := range cols {
+		slices := make([]arrow.Array, len(order))
+		for i, row := range order {
+			slices[i] = array.NewSlice(rec.Column(c), int64(row), int64(row+1))
+		}
+		cols[c], err = array.Concatenate(slices, memory.NewGoAllocator())
+		for _, s := range slices {
+			s.Release()
+		}
+		if err != nil {
+			rec.Release()
+			return nil, fmt.Errorf("execute page: failed to reorder results: %w", err)
+		}
+	}
+	sorted := array.NewRecord(rec.Schema(), cols, rec.NumRows())
+	rec.Release()
+	return sorted, nil
+}
+
+// concatRecords joins records sharing one schema into a single record.
+// The inputs keep their own references.
+func concatRecords(records []arrow.Record) arrow.Record {
+	if len(records) == 1 {
+		records[0].Retain()
+		return records[0]
+	}
+	table := array.NewTableFromRecords(records[0].Schema(), records)
+	defer table.Release()
+	tr := array.NewTableReader(table, max(table.NumRows(), 1))
+	defer tr.Release()
+	tr.Next()
+	rec := tr.Record()
+	rec.Retain()
+	return rec
+}
+
+// dropColumn returns rec without the named column, releasing rec.
+func dropColumn(rec arrow.Record, name string) arrow.Record {
+	idx := rec.Schema().FieldIndices(name)
+	if len(idx) != 1 {
+		return rec
+	}
+	fields := make([]arrow.Field, 0, rec.NumCols()-1)
+	cols := make([]arrow.Array, 0, rec.NumCols()-1)
+	for i, f := range rec.Schema().Fields() {
+		if i != idx[0] {
+			fields = append(fields, f)
+			cols = append(cols, rec.Column(i))
+		}
+	}
+	md := rec.Schema().Metadata()
+	out := array.NewRecord(arrow.NewSchema(fields, &md), cols, rec.NumRows())
+	rec.Release()
+	return out
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+)
+
+// recordStream is an array.RecordReader that pulls one record batch at
+// a time from a Rust stream handle. The handle is closed when the
+// stream is exhausted, fails, or the last reference is released.
+type recordStream struct {
+	refs int64
+	ctx  context.Context
+
+	mu sync.Mutex
+	// #nosec G103 - FFI handle for C interop with Rust library
+	handle unsafe.Pointer
+	schema *arrow.Schema
+	cur    arrow.Record
+	err    error
+}
+
+var _ array.RecordReader = (*recordStream)(nil)
+
+// newRecordStream takes ownership of handle. On error the handle has
+// already been closed.
+//
+// #nosec G103 - Function parameter for FFI handle from C interop
+func newRecordStream(ctx context.Context, handle unsafe.Pointer) (*recordStream, error) {
+	s := &recordStream{refs: 1, ctx: ctx, handle: handle}
+
+	var schemaIPCData *C.uchar
+	var schemaIPCLen C.size_t
+	result := C.simple_lancedb_stream_schema(handle, &schemaIPCData, &schemaIPCLen)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		s.closeHandle()
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to read stream schema: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to read stream schema: unknown error")
+	}
+	// #nosec G103 - Safe conversion of C memory to Go bytes for Arrow IPC data
+	schemaBytes := C.GoBytes(unsafe.Pointer(schemaIPCData), C.int(schemaIPCLen))
+	C.simple_lancedb_free_ipc_data(schemaIPCData)
+
+	schema, err := ipcBytesToSchema(schemaBytes)
+	if err != nil {
+		s.closeHandle()
+		return nil, err
+	}
+	s.schema = schema
+
+	runtime.SetFinalizer(s, (*recordStream).closeHandle)
+	return s, nil
+}
+
+// Retain increases the reference count.
+func (s *recordStream) Retain() {
+	atomic.AddInt64(&s.refs, 1)
+}
+
+// Release decreases the reference count and closes the stream when it
+// reaches zero.
+func (s *recordStream) Release() {
+	if atomic.AddInt64(&s.refs, -1) != 0 {
+		return
+	}
+	s.mu.Lock()
+	if s.cur != nil {
+		s.cur.Release()
+		s.cur = nil
+	}
+	s.mu.Unlock()
+	s.closeHandle()
+}
+
+// Schema returns the schema of every record in the stream.
+func (s *recordStream) Schema() *arrow.Schema {
+	return s.schema
+}
+
+// Next pulls the next record from the stream. It returns false at the
+// end of the stream, on error (see Err), or once ctx is done.
+func (s *recordStream) Next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cur != nil {
+		s.cur.Release()
+		s.cur = nil
+	}
+	if s.handle == nil || s.err != nil {
+		return false
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.err = err
+		s.closeHandleLocked()
+		return false
+	}
+
+	var batchIPCData *C.uchar
+	var batchIPCLen C.size_t
+	result := C.simple_lancedb_stream_next(s.handle, &batchIPCData, &batchIPCLen)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			s.err = fmt.Errorf("failed to read stream: %s", C.GoString(result.ERROR_MESSAGE))
+		} else {
+			s.err = fmt.Errorf("failed to read stream: unknown error")
+		}
+		s.closeHandleLocked()
+		return false
+	}
+	if batchIPCData == nil || batchIPCLen == 0 {
+		s.closeHandleLocked()
+		return false
+	}
+
+	// Guard against integer truncation for payloads > 2GB
+	if batchIPCLen > C.size_t(math.MaxInt32) {
+		C.simple_lancedb_free_ipc_data(batchIPCData)
+		s.err = fmt.Errorf("stream batch too large (%d bytes) to copy", batchIPCLen)
+		s.closeHandleLocked()
+		return false
+	}
+
+	// #nosec G103 - Safe conversion of C memory to Go bytes for Arrow IPC data
+	ipcBytes := C.GoBytes(unsafe.Pointer(batchIPCData), C.int(batchIPCLen))
+	C.simple_lancedb_free_ipc_data(batchIPCData)
+
+	rec, err := ipcBytesToRecord(ipcBytes)
+	if err != nil {
+		s.err = err
+		s.closeHandleLocked()
+		return false
+	}
+	s.cur = rec
+	return true
+}
+
+// Record returns the current record. It is valid until the next call
+// to Next or Release.
+func (s *recordStream) Record() arrow.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cur
+}
+
+// Err returns the error that stopped the stream, if any.
+func (s *recordStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *recordStream) closeHandle() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeHandleLocked()
+}
+
+func (s *recordStream) closeHandleLocked() {
+	if s.handle == nil {
+		return
+	}
+	result := C.simple_lancedb_stream_close(s.handle)
+	C.simple_lancedb_result_free(result)
+	s.handle = nil
+	runtime.SetFinalizer(s, nil)
+}
@@ -41,7 +41,9 @@ func NewSchema(schema *arrow.Schema) (lancedb.ISchema, error) {
 
 // SchemaBuilder provides a fluent interface for building schemas
 type SchemaBuilder struct {
-	fields []arrow.Field
+	fields   []arrow.Field
+	metadata map[string]string
+	err      error
 }
 
 var _ lancedb.ISchemaBuilder = (*SchemaBuilder)(nil)
@@ -129,9 +131,50 @@ func (sb *SchemaBuilder) AddTimestampField(name string, unit arrow.TimeUnit, nul
 	return sb.AddField(name, timestampType, nullable)
 }
 
+// WithMetadata merges metadata into the schema-level metadata
+func (sb *SchemaBuilder) WithMetadata(metadata map[string]string) lancedb.ISchemaBuilder {
+	if sb.metadata == nil {
+		sb.metadata = make(map[string]string, len(metadata))
+	}
+	for k, v := range metadata {
+		sb.metadata[k] = v
+	}
+	return sb
+}
+
+// WithFieldMetadata merges metadata into the metadata of an added field
+func (sb *SchemaBuilder) WithFieldMetadata(name string, metadata map[string]string) lancedb.ISchemaBuilder {
+	for i := range sb.fields {
+		if sb.fields[i].Name != name {
+			continue
+		}
+		merged := make(map[string]string, sb.fields[i].Metadata.Len()+len(metadata))
+		for j, k := range sb.fields[i].Metadata.Keys() {
+			merged[k] = sb.fields[i].Metadata.Values()[j]
+		}
+		for k, v := range metadata {
+			merged[k] = v
+		}
+		sb.fields[i].Metadata = arrow.MetadataFrom(merged)
+		return sb
+	}
+	if sb.err == nil {
+		sb.err = fmt.Errorf("field '%s' not found for metadata", name)
+	}
+	return sb
+}
+
 // Build creates the final schema
 func (sb *SchemaBuilder) Build() (lancedb.ISchema, error) {
-	arrowSchema := arrow.NewSchema(sb.fields, nil)
+	if sb.err != nil {
+		return nil, sb.err
+	}
+	var metadata *arrow.Metadata
+	if len(sb.metadata) > 0 {
+		md := arrow.MetadataFrom(sb.metadata)
+		metadata = &md
+	}
+	arrowSchema := arrow.NewSchema(sb.fields, metadata)
 	return NewSchema(arrowSchema)
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/arrow/go/v17/arrow"
 	"github.com/apache/arrow/go/v17/arrow/array"
 	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/apache/arrow/go/v17/arrow/memory"
 
 	"github.com/lancedb/lancedb-go/pkg/contracts"
 )
@@ -35,6 +36,12 @@ type Table struct {
 	handle unsafe.Pointer
 	mu     sync.RWMutex
 	closed bool
+	// readOnly marks a snapshot handle returned by AsOf. Set once
+	// before the handle is published, never cleared.
+	readOnly bool
+	// branch is the branch this handle tracks; empty for main. Set once
+	// by CheckoutBranch before the handle is published.
+	branch string
 }
 
 // Compile-time check to ensure Table implements ITable interface
@@ -224,18 +231,27 @@ func (t *Table) Query() contracts.IQueryBuilder {
 
 // VectorQuery creates a new vector query builder for this Table
 func (t *Table) VectorQuery(column string, vector []float32) contracts.IVectorQueryBuilder {
-	var vectorCopy []float32
+	var arr arrow.Array
 	if vector != nil {
-		vectorCopy = make([]float32, len(vector))
-		copy(vectorCopy, vector)
+		b := array.NewFloat32Builder(memory.NewGoAllocator())
+		b.AppendValues(vector, nil)
+		arr = b.NewArray()
+		b.Release()
 	}
+	return t.newVectorQuery(column, arr)
+}
+
+// newVectorQuery builds a VectorQueryBuilder around an Arrow-encoded
+// query vector. Shared by VectorQuery and the typed variants in
+// table_typed_vector_query.go.
+func (t *Table) newVectorQuery(column string, vector arrow.Array) *VectorQueryBuilder {
 	return &VectorQueryBuilder{
 		QueryBuilder: QueryBuilder{
 			table:   t,
 			filters: make([]string, 0),
 			columns: nil,
 		},
-		vector: vectorCopy,
+		vector: vector,
 		column: column,
 	}
 }
@@ -736,6 +752,42 @@ func ComputeWaitTimeoutMs(ctx context.Context, timeout time.Duration) uint64 {
 	return uint64(ms)
 }
 
+// encodeSelectConfig splits a QueryConfig into its JSON form and, for
+// vector searches, the Arrow IPC encoding of the query vector. The vector
+// travels as Arrow so non-float32 element types survive the FFI boundary;
+// it is stripped from the JSON so it is not sent twice.
+//
+//nolint:gocritic
+func encodeSelectConfig(config contracts.QueryConfig) ([]byte, []byte, error) {
+	var vectorIPC []byte
+	if config.VectorSearch != nil {
+		vs := *config.VectorSearch
+		vector := vs.QueryVector
+		if vector == nil && len(vs.Vector) > 0 {
+			b := array.NewFloat32Builder(memory.NewGoAllocator())
+			b.AppendValues(vs.Vector, nil)
+			vector = b.NewArray()
+			b.Release()
+			defer vector.Release()
+		}
+		if vector != nil {
+			var err error
+			vectorIPC, err = queryVectorToIPCBytes(vector)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to encode query vector: %w", err)
+			}
+		}
+		vs.Vector = nil
+		config.VectorSearch = &vs
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal query config to JSON: %w", err)
+	}
+	return configJSON, vectorIPC, nil
+}
+
 // Select executes a select query with various predicates (vector search, filters, etc.)
 //
 //nolint:gocritic
@@ -748,17 +800,23 @@ func (t *Table) Select(_ context.Context, config contracts.QueryConfig) ([]map[s
 	}
 
 	// Convert lancedb.QueryConfig to JSON
-	configJSON, err := json.Marshal(config)
+	configJSON, vectorIPC, err := encodeSelectConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal query config to JSON: %w", err)
+		return nil, err
 	}
 
 	cConfigJSON := C.CString(string(configJSON))
 	// #nosec G103 - Required for freeing C allocated string memory
 	defer C.free(unsafe.Pointer(cConfigJSON))
 
+	var vectorPtr *C.uchar
+	if len(vectorIPC) > 0 {
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		vectorPtr = (*C.uchar)(unsafe.Pointer(&vectorIPC[0]))
+	}
+
 	var resultJSON *C.char
-	result := C.simple_lancedb_table_select_query(t.handle, cConfigJSON, &resultJSON)
+	result := C.simple_lancedb_table_select_query_v2(t.handle, cConfigJSON, vectorPtr, C.size_t(uintptr(len(vectorIPC))), &resultJSON)
 	defer C.simple_lancedb_result_free(result)
 
 	if !result.SUCCESS {
@@ -797,18 +855,31 @@ func (t *Table) SelectIPC(_ context.Context, config contracts.QueryConfig) ([]by
 		return nil, fmt.Errorf("table is closed")
 	}
 
-	configJSON, err := json.Marshal(config)
+	configJSON, vectorIPC, err := encodeSelectConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal query config to JSON: %w", err)
+		return nil, err
 	}
 
 	cConfigJSON := C.CString(string(configJSON))
 	// #nosec G103 - Required for freeing C allocated string memory
 	defer C.free(unsafe.Pointer(cConfigJSON))
 
+	var vectorPtr *C.uchar
+	if len(vectorIPC) > 0 {
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		vectorPtr = (*C.uchar)(unsafe.Pointer(&vectorIPC[0]))
+	}
+
 	var resultIPCData *C.uchar
 	var resultIPCLen C.size_t
-	result := C.simple_lancedb_table_select_query_ipc(t.handle, cConfigJSON, &resultIPCData, &resultIPCLen)
+	result := C.simple_lancedb_table_select_query_ipc_v2(
+		t.handle,
+		cConfigJSON,
+		vectorPtr,
+		C.size_t(uintptr(len(vectorIPC))),
+		&resultIPCData,
+		&resultIPCLen,
+	)
 	defer C.simple_lancedb_result_free(result)
 
 	if !result.SUCCESS {
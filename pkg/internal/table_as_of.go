@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the read-only snapshot
+// capability extension.
+var _ contracts.ITableAsOf = (*Table)(nil)
+
+// AsOf opens an independent read-only handle pinned to ref. The
+// receiver's own pin is left untouched.
+func (t *Table) AsOf(ctx context.Context, ref contracts.VersionRef) (contracts.ITable, error) {
+	return t.asOf(ctx, ref)
+}
+
+// asOf re-opens the table (on the same branch) and checks the fresh
+// handle out at ref. The fresh handle has its own dataset state in
+// lancedb, so checking it out cannot disturb concurrent users of t.
+func (t *Table) asOf(ctx context.Context, ref contracts.VersionRef) (*Table, error) {
+	set := 0
+	for _, ok := range []bool{ref.Version != 0, ref.Tag != "", !ref.Time.IsZero()} {
+		if ok {
+			set++
+		}
+	}
+	if set == 0 {
+		return nil, fmt.Errorf("as_of: a version, tag or time is required")
+	}
+	if set > 1 {
+		return nil, fmt.Errorf("as_of: set exactly one of version, tag or time")
+	}
+	if !ref.Time.IsZero() {
+		version, err := t.ResolveVersionAt(ctx, ref.Time)
+		if err != nil {
+			return nil, fmt.Errorf("as_of: %w", err)
+		}
+		ref = contracts.AtVersion(version)
+	}
+
+	pinned, err := t.reopen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("as_of: %w", err)
+	}
+
+	if ref.Tag != "" {
+		err = pinned.CheckoutTag(ctx, ref.Tag)
+	} else {
+		err = pinned.Checkout(ctx, ref.Version)
+	}
+	if err != nil {
+		_ = pinned.Close()
+		return nil, fmt.Errorf("as_of: %w", err)
+	}
+
+	pinned.readOnly = true
+	return pinned, nil
+}
+
+// reopen opens a fresh handle on the same table and branch as t, with
+// its own dataset state in lancedb.
+func (t *Table) reopen(ctx context.Context) (*Table, error) {
+	t.mu.RLock()
+	closed := t.closed || t.handle == nil
+	conn, name, branch := t.connection, t.name, t.branch
+	t.mu.RUnlock()
+
+	if closed {
+		return nil, fmt.Errorf("table is closed")
+	}
+	if conn == nil {
+		return nil, fmt.Errorf("table %s has no connection to reopen from", name)
+	}
+
+	opened, err := conn.OpenTable(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	fresh, ok := opened.(*Table)
+	if !ok {
+		_ = opened.Close()
+		return nil, fmt.Errorf("unexpected table type %T", opened)
+	}
+	if branch == "" {
+		return fresh, nil
+	}
+
+	defer fresh.Close()
+	return fresh.checkoutBranch(branch)
+}
@@ -0,0 +1,450 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the backfill capability
+// extension.
+var _ contracts.ITableBackfill = (*Table)(nil)
+
+// backfillCheckpoint is the on-disk record of a partially finished
+// AddColumnsFromFunc. Fragments maps fragment ID to the rewritten
+// fragment manifest entry returned by the backend. A checkpoint only
+// applies to the same table, read version and output columns.
+type backfillCheckpoint struct {
+	Table       string                     `json:"table"`
+	ReadVersion uint64                     `json:"read_version"`
+	Columns     []string                   `json:"columns"`
+	Fragments   map[uint64]json.RawMessage `json:"fragments"`
+}
+
+// AddColumnsFromFunc adds the columns of outputSchema, computed by fn
+// over readColumns, without checkpointing.
+func (t *Table) AddColumnsFromFunc(ctx context.Context, outputSchema *arrow.Schema, readColumns []string, fn contracts.ColumnFunc) (uint64, error) {
+	return t.AddColumnsFromFuncWithOptions(ctx, outputSchema, readColumns, fn, nil)
+}
+
+// AddColumnsFromFuncWithOptions adds the columns of outputSchema,
+// computed by fn over readColumns one fragment at a time, then commits
+// them as a single version.
+func (t *Table) AddColumnsFromFuncWithOptions(ctx context.Context, outputSchema *arrow.Schema, readColumns []string,
+	fn contracts.ColumnFunc, opts *contracts.AddColumnsFromFuncOptions) (uint64, error) {
+	if outputSchema == nil || len(outputSchema.Fields()) == 0 {
+		return 0, fmt.Errorf("add_columns_from_func: output schema must have at least one field")
+	}
+	if len(readColumns) == 0 {
+		return 0, fmt.Errorf("add_columns_from_func: read columns must be non-empty")
+	}
+	if fn == nil {
+		return 0, fmt.Errorf("add_columns_from_func: fn is nil")
+	}
+	if err := t.checkWritable(); err != nil {
+		return 0, err
+	}
+
+	current, err := t.Schema(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range readColumns {
+		if _, ok := current.FieldsByName(c); !ok {
+			return 0, fmt.Errorf("add_columns_from_func: read column %q not found", c)
+		}
+	}
+	outputNames := make([]string, 0, len(outputSchema.Fields()))
+	for _, f := range outputSchema.Fields() {
+		if _, ok := current.FieldsByName(f.Name); ok {
+			return 0, fmt.Errorf("add_columns_from_func: column %q already exists", f.Name)
+		}
+		outputNames = append(outputNames, f.Name)
+	}
+
+	version, err := t.Version(ctx)
+	if err != nil {
+		return 0, err
+	}
+	readVersion := uint64(version)
+
+	var checkpointPath string
+	if opts != nil {
+		checkpointPath = opts.CheckpointPath
+	}
+	ckpt := loadBackfillCheckpoint(checkpointPath, t.name, readVersion, outputNames)
+
+	fragmentIDs, err := t.fragmentIDs()
+	if err != nil {
+		return 0, err
+	}
+	columnsJSON, err := json.Marshal(readColumns)
+	if err != nil {
+		return 0, fmt.Errorf("add_columns_from_func: marshal read columns: %w", err)
+	}
+
+	for _, id := range fragmentIDs {
+		if _, done := ckpt.Fragments[id]; done {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		fragment, err := t.backfillFragment(ctx, id, columnsJSON, outputSchema, fn)
+		if err != nil {
+			return 0, fmt.Errorf("add_columns_from_func: fragment %d: %w", id, err)
+		}
+		ckpt.Fragments[id] = fragment
+		if err := saveBackfillCheckpoint(checkpointPath, ckpt); err != nil {
+			return 0, err
+		}
+	}
+
+	fragments := make([]json.RawMessage, 0, len(fragmentIDs))
+	for _, id := range fragmentIDs {
+		fragments = append(fragments, ckpt.Fragments[id])
+	}
+	newVersion, err := t.commitMergeColumns(readVersion, fragments, outputSchema)
+	if err != nil {
+		return 0, err
+	}
+	if checkpointPath != "" {
+		_ = os.Remove(checkpointPath)
+	}
+	return newVersion, nil
+}
+
+// checkWritable rejects closed and read-only handles.
+func (t *Table) checkWritable() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return fmt.Errorf("failed to add columns: %w", contracts.ErrReadOnlyTable)
+	}
+	return nil
+}
+
+// backfillFragment streams one fragment through fn a batch at a time:
+// each input batch is read from the backend's fragment updater and its
+// output handed straight back, so neither side holds more than one
+// batch. Returns the rewritten fragment entry; a fragment with no live
+// rows comes back unchanged. The table lock is not held while fn runs,
+// so fn may use the table.
+func (t *Table) backfillFragment(ctx context.Context, id uint64, columnsJSON []byte, outputSchema *arrow.Schema,
+	fn contracts.ColumnFunc) (json.RawMessage, error) {
+	u, err := t.openFragmentUpdater(id, columnsJSON)
+	if err != nil {
+		return nil, err
+	}
+	defer u.close()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		in, err := u.next()
+		if err != nil {
+			return nil, err
+		}
+		if in == nil {
+			break
+		}
+		err = computeBatch(u, in, outputSchema, fn)
+		in.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return u.finish()
+}
+
+// computeBatch runs fn over one input batch and hands the checked
+// output to the updater.
+func computeBatch(u *fragmentUpdater, in arrow.Record, outputSchema *arrow.Schema, fn contracts.ColumnFunc) error {
+	out, err := fn(in)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return fmt.Errorf("fn returned a nil record")
+	}
+	defer out.Release()
+	if out.NumRows() != in.NumRows() {
+		return fmt.Errorf("fn returned %d rows for a batch of %d", out.NumRows(), in.NumRows())
+	}
+	if err := matchOutputSchema(out.Schema(), outputSchema); err != nil {
+		return err
+	}
+	return u.update(out)
+}
+
+// matchOutputSchema checks that a record returned by fn has the
+// declared output columns, in order, with the declared types.
+func matchOutputSchema(got, want *arrow.Schema) error {
+	if len(got.Fields()) != len(want.Fields()) {
+		return fmt.Errorf("fn returned %d columns, want %d", len(got.Fields()), len(want.Fields()))
+	}
+	for i, f := range want.Fields() {
+		g := got.Field(i)
+		if g.Name != f.Name || !arrow.TypeEqual(g.Type, f.Type) {
+			return fmt.Errorf("fn returned column %d as %s %s, want %s %s", i, g.Name, g.Type, f.Name, f.Type)
+		}
+	}
+	return nil
+}
+
+// fragmentIDs lists the fragments of the version the handle sees.
+func (t *Table) fragmentIDs() ([]uint64, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	var idsJSON *C.char
+	result := C.simple_lancedb_table_fragment_ids(t.handle, &idsJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to list fragments: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to list fragments: unknown error")
+	}
+	if idsJSON == nil {
+		return nil, nil
+	}
+	jsonStr := C.GoString(idsJSON)
+	C.simple_lancedb_free_string(idsJSON)
+
+	var ids []uint64
+	if err := json.Unmarshal([]byte(jsonStr), &ids); err != nil {
+		return nil, fmt.Errorf("fragment_ids: failed to parse result JSON: %w", err)
+	}
+	return ids, nil
+}
+
+// fragmentUpdater drives a backend fragment updater handle. It is
+// used by a single goroutine and must be closed.
+type fragmentUpdater struct {
+	id uint64
+	// #nosec G103 - FFI handle for C interop with Rust library
+	handle unsafe.Pointer
+}
+
+// openFragmentUpdater opens an updater over one fragment of the
+// version the handle sees, reading the columns in columnsJSON.
+func (t *Table) openFragmentUpdater(id uint64, columnsJSON []byte) (*fragmentUpdater, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	cColumns := C.CString(string(columnsJSON))
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cColumns))
+
+	// #nosec G103 - FFI handle for C interop with Rust library
+	var handle unsafe.Pointer
+	result := C.simple_lancedb_table_fragment_updater_open(t.handle, C.uint64_t(id), cColumns, &handle)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to read fragment %d: %s", id, C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to read fragment %d: unknown error", id)
+	}
+	return &fragmentUpdater{id: id, handle: handle}, nil
+}
+
+// next returns the next batch of input columns, or nil at the end of
+// the fragment. The caller releases the record.
+func (u *fragmentUpdater) next() (arrow.Record, error) {
+	var batchIPCData *C.uchar
+	var batchIPCLen C.size_t
+	result := C.simple_lancedb_fragment_updater_next(u.handle, &batchIPCData, &batchIPCLen)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to read fragment %d: %s", u.id, C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to read fragment %d: unknown error", u.id)
+	}
+	if batchIPCData == nil || batchIPCLen == 0 {
+		return nil, nil
+	}
+	defer C.simple_lancedb_free_ipc_data(batchIPCData)
+
+	if batchIPCLen > C.size_t(math.MaxInt32) {
+		return nil, fmt.Errorf("fragment batch too large: %d bytes", batchIPCLen)
+	}
+	// #nosec G103 - Safe conversion of C memory to Go bytes for Arrow IPC data
+	ipcBytes := C.GoBytes(unsafe.Pointer(batchIPCData), C.int(batchIPCLen))
+	return ipcBytesToRecord(ipcBytes)
+}
+
+// update hands back the computed columns for the batch last returned
+// by next.
+func (u *fragmentUpdater) update(out arrow.Record) error {
+	computed, err := recordsToIPCBytes([]arrow.Record{out})
+	if err != nil {
+		return err
+	}
+	result := C.simple_lancedb_fragment_updater_update(u.handle,
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		(*C.uchar)(unsafe.Pointer(&computed[0])), C.size_t(len(computed)))
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return fmt.Errorf("failed to write columns for fragment %d: %s", u.id, C.GoString(result.ERROR_MESSAGE))
+		}
+		return fmt.Errorf("failed to write columns for fragment %d: unknown error", u.id)
+	}
+	return nil
+}
+
+// finish writes the new column files and returns the rewritten
+// fragment entry. Nothing is committed.
+func (u *fragmentUpdater) finish() (json.RawMessage, error) {
+	var fragmentJSON *C.char
+	result := C.simple_lancedb_fragment_updater_finish(u.handle, &fragmentJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to write columns for fragment %d: %s", u.id, C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to write columns for fragment %d: unknown error", u.id)
+	}
+	if fragmentJSON == nil {
+		return nil, fmt.Errorf("received null fragment JSON")
+	}
+	jsonStr := C.GoString(fragmentJSON)
+	C.simple_lancedb_free_string(fragmentJSON)
+	return json.RawMessage(jsonStr), nil
+}
+
+// close releases the updater handle.
+func (u *fragmentUpdater) close() {
+	if u.handle == nil {
+		return
+	}
+	result := C.simple_lancedb_fragment_updater_close(u.handle)
+	C.simple_lancedb_result_free(result)
+	u.handle = nil
+}
+
+// commitMergeColumns commits the rewritten fragments as one version
+// adding the columns of outputSchema.
+func (t *Table) commitMergeColumns(readVersion uint64, fragments []json.RawMessage, outputSchema *arrow.Schema) (uint64, error) {
+	fragmentsJSON, err := json.Marshal(fragments)
+	if err != nil {
+		return 0, fmt.Errorf("add_columns_from_func: marshal fragments: %w", err)
+	}
+	schemaIPC, err := schemaToIPCBytes(outputSchema)
+	if err != nil {
+		return 0, fmt.Errorf("add_columns_from_func: encode output schema: %w", err)
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return 0, fmt.Errorf("table is closed")
+	}
+
+	cFragments := C.CString(string(fragmentsJSON))
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cFragments))
+
+	var version C.uint64_t
+	result := C.simple_lancedb_table_commit_merge_columns(t.handle, C.uint64_t(readVersion), cFragments,
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		(*C.uchar)(unsafe.Pointer(&schemaIPC[0])), C.size_t(len(schemaIPC)), &version)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return 0, fmt.Errorf("failed to add columns: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return 0, fmt.Errorf("failed to add columns: unknown error")
+	}
+	return uint64(version), nil
+}
+
+// loadBackfillCheckpoint returns the checkpoint at path when it belongs
+// to the same backfill, or an empty one otherwise. A missing or
+// unreadable file starts from scratch.
+func loadBackfillCheckpoint(path, table string, readVersion uint64, columns []string) *backfillCheckpoint {
+	fresh := &backfillCheckpoint{
+		Table:       table,
+		ReadVersion: readVersion,
+		Columns:     columns,
+		Fragments:   map[uint64]json.RawMessage{},
+	}
+	if path == "" {
+		return fresh
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fresh
+	}
+	var ckpt backfillCheckpoint
+	if err := json.Unmarshal(data, &ckpt); err != nil {
+		return fresh
+	}
+	if ckpt.Table != table || ckpt.ReadVersion != readVersion ||
+		strings.Join(ckpt.Columns, "\x00") != strings.Join(columns, "\x00") || ckpt.Fragments == nil {
+		return fresh
+	}
+	return &ckpt
+}
+
+// saveBackfillCheckpoint atomically replaces the checkpoint at path.
+// A no-op when path is empty.
+func saveBackfillCheckpoint(path string, ckpt *backfillCheckpoint) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(ckpt)
+	if err != nil {
+		return fmt.Errorf("add_columns_from_func: marshal checkpoint: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("add_columns_from_func: write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Join(fmt.Errorf("add_columns_from_func: write checkpoint: %w", err), os.Remove(tmp))
+	}
+	return nil
+}
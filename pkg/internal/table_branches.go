@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the branch capability
+// extension.
+var _ contracts.ITableBranches = (*Table)(nil)
+
+// BranchCreate cuts a new branch from the version, tag or instant named
+// by from.
+func (t *Table) BranchCreate(ctx context.Context, name string, from contracts.VersionRef) error {
+	if name == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+	if !from.Time.IsZero() {
+		if from.Version != 0 || from.Tag != "" {
+			return fmt.Errorf("failed to create branch %q: set exactly one of version, tag or time", name)
+		}
+		version, err := t.ResolveVersionAt(ctx, from.Time)
+		if err != nil {
+			return fmt.Errorf("failed to create branch %q: %w", name, err)
+		}
+		from = contracts.AtVersion(version)
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return fmt.Errorf("failed to create branch: %w", contracts.ErrReadOnlyTable)
+	}
+
+	cName := C.CString(name)
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cName))
+
+	var cTag *C.char
+	if from.Tag != "" {
+		cTag = C.CString(from.Tag)
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cTag))
+	}
+
+	result := C.simple_lancedb_table_branch_create(t.handle, cName, C.uint64_t(from.Version), cTag)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return fmt.Errorf("failed to create branch %q: %s", name, C.GoString(result.ERROR_MESSAGE))
+		}
+		return fmt.Errorf("failed to create branch %q: unknown error", name)
+	}
+	return nil
+}
+
+// BranchList returns every branch on the table, keyed by name.
+func (t *Table) BranchList(_ context.Context) (map[string]contracts.BranchInfo, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	var branchesJSON *C.char
+	result := C.simple_lancedb_table_branch_list(t.handle, &branchesJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to list branches: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to list branches: unknown error")
+	}
+
+	if branchesJSON == nil {
+		return map[string]contracts.BranchInfo{}, nil
+	}
+	jsonStr := C.GoString(branchesJSON)
+	C.simple_lancedb_free_string(branchesJSON)
+
+	// created_at crosses the FFI as unix seconds.
+	var raw map[string]struct {
+		ParentBranch  string `json:"parent_branch"`
+		ParentVersion uint64 `json:"parent_version"`
+		CreatedAt     int64  `json:"created_at"`
+		ManifestSize  uint64 `json:"manifest_size"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &raw); err != nil {
+		return nil, fmt.Errorf("branch_list: failed to parse result JSON: %w", err)
+	}
+	branches := make(map[string]contracts.BranchInfo, len(raw))
+	for name, b := range raw {
+		branches[name] = contracts.BranchInfo{
+			ParentBranch:  b.ParentBranch,
+			ParentVersion: b.ParentVersion,
+			CreatedAt:     time.Unix(b.CreatedAt, 0).UTC(),
+			ManifestSize:  b.ManifestSize,
+		}
+	}
+	return branches, nil
+}
+
+// CheckoutBranch opens a new writable handle on the head of the named
+// branch. The receiver is left untouched.
+func (t *Table) CheckoutBranch(_ context.Context, name string) (contracts.ITable, error) {
+	if name == "" {
+		return nil, fmt.Errorf("branch name cannot be empty")
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return nil, fmt.Errorf("failed to checkout branch: %w", contracts.ErrReadOnlyTable)
+	}
+	return t.checkoutBranch(name)
+}
+
+// checkoutBranch is the body of CheckoutBranch. Callers must hold t.mu
+// (or own t exclusively) and have checked that t is open.
+func (t *Table) checkoutBranch(name string) (*Table, error) {
+	cName := C.CString(name)
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cName))
+
+	// #nosec G103 - FFI handle for table from C interop
+	var branchHandle unsafe.Pointer
+	result := C.simple_lancedb_table_checkout_branch(t.handle, cName, &branchHandle)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to checkout branch %q: %s", name, C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to checkout branch %q: unknown error", name)
+	}
+
+	branchTable := &Table{
+		name:       t.name,
+		connection: t.connection,
+		handle:     branchHandle,
+		branch:     name,
+	}
+	runtime.SetFinalizer(branchTable, (*Table).Close)
+	return branchTable, nil
+}
+
+// BranchDelete deletes the named branch.
+func (t *Table) BranchDelete(_ context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return fmt.Errorf("failed to delete branch: %w", contracts.ErrReadOnlyTable)
+	}
+
+	cName := C.CString(name)
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cName))
+
+	result := C.simple_lancedb_table_branch_delete(t.handle, cName)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return fmt.Errorf("failed to delete branch %q: %s", name, C.GoString(result.ERROR_MESSAGE))
+		}
+		return fmt.Errorf("failed to delete branch %q: unknown error", name)
+	}
+	return nil
+}
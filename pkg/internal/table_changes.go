@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow/array"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the change data feed
+// capability extension.
+var _ contracts.ITableChanges = (*Table)(nil)
+
+// Changes returns the rows inserted, updated or deleted between
+// fromVersion and toVersion. Both ends are opened as independent
+// snapshot handles (see AsOf), so the receiver's pin never moves; the
+// returned stream holds its own snapshots and outlives them.
+func (t *Table) Changes(ctx context.Context, fromVersion, toVersion uint64) (array.RecordReader, error) {
+	if fromVersion == 0 {
+		return nil, fmt.Errorf("changes: fromVersion must be at least 1")
+	}
+	if fromVersion >= toVersion {
+		return nil, fmt.Errorf("changes: fromVersion (%d) must be older than toVersion (%d)", fromVersion, toVersion)
+	}
+
+	from, err := t.asOf(ctx, contracts.AtVersion(fromVersion))
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	defer from.Close()
+
+	to, err := t.asOf(ctx, contracts.AtVersion(toVersion))
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	defer to.Close()
+
+	// Both handles are private to this call, but take their read locks
+	// anyway so Close from a finalizer cannot race the FFI call.
+	from.mu.RLock()
+	defer from.mu.RUnlock()
+	to.mu.RLock()
+	defer to.mu.RUnlock()
+
+	// #nosec G103 - FFI handle for the result stream from C interop
+	var streamHandle unsafe.Pointer
+	result := C.simple_lancedb_table_changes(to.handle, from.handle, C.uint64_t(fromVersion), &streamHandle)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to compute changes: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to compute changes: unknown error")
+	}
+
+	return newRecordStream(ctx, streamHandle)
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the checkout-by-timestamp
+// capability extension.
+var _ contracts.ITableCheckoutAt = (*Table)(nil)
+
+// ResolveVersionAt returns the latest version whose commit timestamp is
+// at or before ts. Resolution happens in Go over ListVersions; lancedb
+// has no timestamp lookup of its own. A pruned version leaves no
+// timestamp behind, so when the version after the candidate was pruned
+// the version live at ts cannot be known and *VersionPrunedError is
+// returned, even if the pruned successor was committed after ts.
+func (t *Table) ResolveVersionAt(ctx context.Context, ts time.Time) (uint64, error) {
+	if ts.IsZero() {
+		return 0, fmt.Errorf("resolve version: timestamp cannot be zero")
+	}
+
+	versions, err := t.ListVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, fmt.Errorf("resolve version: table has no versions")
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+
+	// next is the index of the first retained version newer than ts.
+	next := sort.Search(len(versions), func(i int) bool { return versions[i].Timestamp.After(ts) })
+	if next > 0 {
+		version := versions[next-1].Version
+		// A retained tag (or a cleanup that kept recent versions) can
+		// leave a gap right after the candidate; one of the missing
+		// versions may be the one that was live at ts.
+		if next < len(versions) && versions[next].Version != version+1 {
+			after := versions[next]
+			return 0, &contracts.VersionPrunedError{At: ts, Oldest: after.Version, OldestTime: after.Timestamp}
+		}
+		return version, nil
+	}
+
+	// Every retained version is newer than ts. If version 1 is still
+	// around the table simply did not exist yet; otherwise the version
+	// that was live at ts has been cleaned up.
+	oldest := versions[0]
+	if oldest.Version > 1 {
+		return 0, &contracts.VersionPrunedError{At: ts, Oldest: oldest.Version, OldestTime: oldest.Timestamp}
+	}
+	return 0, fmt.Errorf("resolve version: table did not exist at %s (created %s)",
+		ts.UTC().Format(time.RFC3339Nano), oldest.Timestamp.UTC().Format(time.RFC3339Nano))
+}
+
+// CheckoutAt pins the table to the latest version committed at or
+// before ts.
+func (t *Table) CheckoutAt(ctx context.Context, ts time.Time) error {
+	version, err := t.ResolveVersionAt(ctx, ts)
+	if err != nil {
+		return err
+	}
+	return t.Checkout(ctx, version)
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"unsafe"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the filtered count
+// capability extension.
+var _ contracts.ITableCountWhere = (*Table)(nil)
+
+// CountWhere counts the rows matching filter.
+func (t *Table) CountWhere(_ context.Context, filter string) (int64, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return 0, fmt.Errorf("table is closed")
+	}
+
+	// A null filter counts every row.
+	var cFilter *C.char
+	if filter != "" {
+		cFilter = C.CString(filter)
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cFilter))
+	}
+
+	var count C.int64_t
+	result := C.simple_lancedb_table_count_rows_where(t.handle, cFilter, &count)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return 0, fmt.Errorf("failed to count rows: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return 0, fmt.Errorf("failed to count rows: unknown error")
+	}
+
+	return int64(count), nil
+}
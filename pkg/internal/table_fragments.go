@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the fragment listing
+// capability extension.
+var _ contracts.ITableFragments = (*Table)(nil)
+
+// Fragments lists the fragments of the checked-out version.
+func (t *Table) Fragments(_ context.Context) ([]contracts.FragmentInfo, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	var fragmentsJSON *C.char
+	result := C.simple_lancedb_table_fragments(t.handle, &fragmentsJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to list fragments: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to list fragments: unknown error")
+	}
+
+	if fragmentsJSON == nil {
+		return []contracts.FragmentInfo{}, nil
+	}
+	jsonStr := C.GoString(fragmentsJSON)
+	C.simple_lancedb_free_string(fragmentsJSON)
+
+	fragments := []contracts.FragmentInfo{}
+	if err := json.Unmarshal([]byte(jsonStr), &fragments); err != nil {
+		return nil, fmt.Errorf("fragments: failed to parse result JSON: %w", err)
+	}
+	return fragments, nil
+}
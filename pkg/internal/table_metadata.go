@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the schema metadata
+// capability extension.
+var _ contracts.ITableMetadata = (*Table)(nil)
+
+// TableMetadata returns the schema-level metadata of the checked-out
+// version.
+func (t *Table) TableMetadata(ctx context.Context) (map[string]string, error) {
+	schema, err := t.Schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return metadataMap(schema.Metadata()), nil
+}
+
+// FieldMetadata returns the metadata of the field at path in the
+// checked-out version.
+func (t *Table) FieldMetadata(ctx context.Context, path string) (map[string]string, error) {
+	schema, err := t.Schema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	field, ok := lookupFieldPath(schema, path)
+	if !ok {
+		return nil, fmt.Errorf("field %q not found", path)
+	}
+	return metadataMap(field.Metadata), nil
+}
+
+// UpdateTableMetadata applies update to the schema-level metadata.
+func (t *Table) UpdateTableMetadata(_ context.Context, update contracts.MetadataUpdate) (uint64, error) {
+	if err := validateMetadataUpdate(update); err != nil {
+		return 0, err
+	}
+	return t.updateMetadata(nil, update)
+}
+
+// UpdateFieldMetadata applies update to the metadata of the field at
+// path.
+func (t *Table) UpdateFieldMetadata(ctx context.Context, path string, update contracts.MetadataUpdate) (uint64, error) {
+	if strings.TrimSpace(path) == "" {
+		return 0, fmt.Errorf("update_field_metadata: path is empty")
+	}
+	if err := validateMetadataUpdate(update); err != nil {
+		return 0, err
+	}
+	schema, err := t.Schema(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := lookupFieldPath(schema, path); !ok {
+		return 0, fmt.Errorf("update_field_metadata: field %q not found", path)
+	}
+	return t.updateMetadata(&path, update)
+}
+
+// updateMetadata commits update against the field at path, or against
+// the schema when path is nil.
+func (t *Table) updateMetadata(path *string, update contracts.MetadataUpdate) (uint64, error) {
+	updateJSON, err := json.Marshal(update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal metadata update: %w", err)
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return 0, fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return 0, fmt.Errorf("failed to update metadata: %w", contracts.ErrReadOnlyTable)
+	}
+
+	var cPath *C.char
+	if path != nil {
+		cPath = C.CString(*path)
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cPath))
+	}
+	cUpdate := C.CString(string(updateJSON))
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cUpdate))
+
+	var version C.uint64_t
+	result := C.simple_lancedb_table_update_metadata(t.handle, cPath, cUpdate, &version)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return 0, fmt.Errorf("failed to update metadata: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return 0, fmt.Errorf("failed to update metadata: unknown error")
+	}
+	return uint64(version), nil
+}
+
+func validateMetadataUpdate(update contracts.MetadataUpdate) error {
+	if len(update.Set) == 0 && len(update.Remove) == 0 && !update.Replace {
+		return fmt.Errorf("update_metadata: update is empty")
+	}
+	for k := range update.Set {
+		if k == "" {
+			return fmt.Errorf("update_metadata: empty key in Set")
+		}
+	}
+	for _, k := range update.Remove {
+		if k == "" {
+			return fmt.Errorf("update_metadata: empty key in Remove")
+		}
+	}
+	return nil
+}
+
+func metadataMap(md arrow.Metadata) map[string]string {
+	m := make(map[string]string, md.Len())
+	for i, k := range md.Keys() {
+		m[k] = md.Values()[i]
+	}
+	return m
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the all-null column
+// capability extension.
+var _ contracts.ITableNullColumns = (*Table)(nil)
+
+// AddNullColumns adds fields as all-null columns. Only the manifest
+// changes; existing data files are untouched.
+func (t *Table) AddNullColumns(ctx context.Context, fields []arrow.Field) (uint64, error) {
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("add_null_columns: fields must be non-empty")
+	}
+	current, err := t.Schema(ctx)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool, len(fields))
+	for i, f := range fields {
+		if strings.TrimSpace(f.Name) == "" {
+			return 0, fmt.Errorf("add_null_columns: fields[%d].Name is empty", i)
+		}
+		if f.Type == nil {
+			return 0, fmt.Errorf("add_null_columns: field %q has no type", f.Name)
+		}
+		if !f.Nullable {
+			return 0, fmt.Errorf("add_null_columns: field %q must be nullable", f.Name)
+		}
+		if seen[f.Name] {
+			return 0, fmt.Errorf("add_null_columns: field %q listed twice", f.Name)
+		}
+		seen[f.Name] = true
+		if _, ok := current.FieldsByName(f.Name); ok {
+			return 0, fmt.Errorf("add_null_columns: column %q already exists", f.Name)
+		}
+	}
+
+	schemaIPC, err := schemaToIPCBytes(arrow.NewSchema(fields, nil))
+	if err != nil {
+		return 0, fmt.Errorf("add_null_columns: encode schema: %w", err)
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return 0, fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return 0, fmt.Errorf("failed to add null columns: %w", contracts.ErrReadOnlyTable)
+	}
+
+	var version C.uint64_t
+	result := C.simple_lancedb_table_add_null_columns(t.handle,
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		(*C.uchar)(unsafe.Pointer(&schemaIPC[0])), C.size_t(len(schemaIPC)), &version)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return 0, fmt.Errorf("failed to add null columns: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return 0, fmt.Errorf("failed to add null columns: unknown error")
+	}
+	return uint64(version), nil
+}
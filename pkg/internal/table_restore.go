@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+	"unsafe"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the one-call restore
+// capability extension.
+var _ contracts.ITableRestore = (*Table)(nil)
+
+// RestoreVersion commits a new latest version equal to version.
+func (t *Table) RestoreVersion(_ context.Context, version uint64, opts *contracts.RestoreOptions) (uint64, error) {
+	if version == 0 {
+		return 0, fmt.Errorf("restore version must be at least 1")
+	}
+	return t.restoreTo(version, "", opts)
+}
+
+// RestoreTag commits a new latest version equal to the version tag
+// points to.
+func (t *Table) RestoreTag(_ context.Context, tag string, opts *contracts.RestoreOptions) (uint64, error) {
+	if tag == "" {
+		return 0, fmt.Errorf("tag name cannot be empty")
+	}
+	return t.restoreTo(0, tag, opts)
+}
+
+// restoreTo runs checkout + restore as one FFI call under the write
+// lock, so no other call on this handle can observe or move the
+// intermediate pin.
+func (t *Table) restoreTo(version uint64, tag string, opts *contracts.RestoreOptions) (uint64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed || t.handle == nil {
+		return 0, fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return 0, fmt.Errorf("failed to restore: %w", contracts.ErrReadOnlyTable)
+	}
+
+	var expected uint64
+	if opts != nil {
+		expected = opts.ExpectedVersion
+	}
+
+	var cTag *C.char
+	if tag != "" {
+		cTag = C.CString(tag)
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cTag))
+	}
+
+	var observed, newVersion C.uint64_t
+	result := C.simple_lancedb_table_restore_to(t.handle, C.uint64_t(version), cTag,
+		C.uint64_t(expected), &observed, &newVersion)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if expected != 0 && observed != 0 && uint64(observed) != expected {
+			return uint64(newVersion), &contracts.VersionConflictError{
+				Expected: expected, Actual: uint64(observed), Committed: uint64(newVersion)}
+		}
+		if result.ERROR_MESSAGE != nil {
+			return 0, fmt.Errorf("failed to restore: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return 0, fmt.Errorf("failed to restore: unknown error")
+	}
+	return uint64(newVersion), nil
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the row-ID mutation
+// capability extension.
+var _ contracts.ITableRowIDs = (*Table)(nil)
+
+// rowIDsToIPCBytes wraps ids in a single UInt64 column and serializes
+// it to Arrow IPC.
+func rowIDsToIPCBytes(ids []uint64) ([]byte, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one row ID must be specified")
+	}
+	b := array.NewUint64Builder(memory.NewGoAllocator())
+	defer b.Release()
+	b.AppendValues(ids, nil)
+	arr := b.NewArray()
+	defer arr.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "_rowid", Type: arrow.PrimitiveTypes.Uint64, Nullable: false}}, nil)
+	rec := array.NewRecord(schema, []arrow.Array{arr}, int64(len(ids)))
+	defer rec.Release()
+	return recordsToIPCBytes([]arrow.Record{rec})
+}
+
+// DeleteRowIDs deletes the rows with the given row IDs in one commit.
+func (t *Table) DeleteRowIDs(_ context.Context, ids []uint64) (*contracts.DeleteResult, error) {
+	ipcBytes, err := rowIDsToIPCBytes(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return nil, fmt.Errorf("failed to delete rows: %w", contracts.ErrReadOnlyTable)
+	}
+
+	var resultJSON *C.char
+	result := C.simple_lancedb_table_delete_row_ids(t.handle,
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		(*C.uchar)(unsafe.Pointer(&ipcBytes[0])), C.size_t(len(ipcBytes)),
+		&resultJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to delete rows: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to delete rows: unknown error")
+	}
+
+	if resultJSON == nil {
+		return &contracts.DeleteResult{}, nil
+	}
+	jsonStr := C.GoString(resultJSON)
+	C.simple_lancedb_free_string(resultJSON)
+
+	var dr contracts.DeleteResult
+	if err := json.Unmarshal([]byte(jsonStr), &dr); err != nil {
+		return nil, fmt.Errorf("delete_row_ids: failed to parse result JSON: %w", err)
+	}
+	return &dr, nil
+}
+
+// UpdateExprRowIDs is UpdateExpr scoped to the rows with the given row
+// IDs. The `_rowid` filter is generated on the Rust side.
+func (t *Table) UpdateExprRowIDs(_ context.Context, ids []uint64, assignments []contracts.UpdateAssignment) (*contracts.UpdateResult, error) {
+	if len(assignments) == 0 {
+		return nil, fmt.Errorf("at least one assignment must be specified")
+	}
+	for i, a := range assignments {
+		if a.Column == "" {
+			return nil, fmt.Errorf("assignment #%d: column name cannot be empty", i)
+		}
+	}
+	assignmentsJSON, err := json.Marshal(assignments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal assignments to JSON: %w", err)
+	}
+	ipcBytes, err := rowIDsToIPCBytes(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return nil, fmt.Errorf("failed to update rows: %w", contracts.ErrReadOnlyTable)
+	}
+
+	cAssignments := C.CString(string(assignmentsJSON))
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cAssignments))
+
+	var resultJSON *C.char
+	result := C.simple_lancedb_table_update_row_ids(t.handle,
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		(*C.uchar)(unsafe.Pointer(&ipcBytes[0])), C.size_t(len(ipcBytes)),
+		cAssignments, &resultJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to update rows: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to update rows: unknown error")
+	}
+
+	if resultJSON == nil {
+		return &contracts.UpdateResult{}, nil
+	}
+	jsonStr := C.GoString(resultJSON)
+	C.simple_lancedb_free_string(resultJSON)
+
+	var ur contracts.UpdateResult
+	if err := json.Unmarshal([]byte(jsonStr), &ur); err != nil {
+		return nil, fmt.Errorf("update_row_ids: failed to parse result JSON: %w", err)
+	}
+	return &ur, nil
+}
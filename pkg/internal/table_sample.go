@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the sample capability
+// extension.
+var _ contracts.ITableSample = (*Table)(nil)
+
+// Sample draws up to n random rows from the table.
+func (t *Table) Sample(_ context.Context, n int, columns []string, seed uint64) (arrow.Record, error) {
+	return t.sample(nil, n, columns, seed)
+}
+
+// SampleWhere draws up to n random rows from those matching filter.
+func (t *Table) SampleWhere(_ context.Context, filter string, n int, columns []string, seed uint64) (arrow.Record, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, fmt.Errorf("failed to sample table: filter cannot be empty")
+	}
+	return t.sample(&filter, n, columns, seed)
+}
+
+// sample is the shared body of Sample and SampleWhere. The result always
+// carries the projected schema, even when no rows are drawn.
+func (t *Table) sample(filter *string, n int, columns []string, seed uint64) (arrow.Record, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("failed to sample table: n must be non-negative, got %d", n)
+	}
+	var columnsJSON []byte
+	if len(columns) > 0 {
+		var err error
+		if columnsJSON, err = json.Marshal(columns); err != nil {
+			return nil, fmt.Errorf("failed to marshal columns: %w", err)
+		}
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	var cColumns *C.char
+	if columnsJSON != nil {
+		cColumns = C.CString(string(columnsJSON))
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cColumns))
+	}
+	var cFilter *C.char
+	if filter != nil {
+		cFilter = C.CString(*filter)
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cFilter))
+	}
+
+	var resultIPCData *C.uchar
+	var resultIPCLen C.size_t
+	result := C.simple_lancedb_table_sample(t.handle, C.size_t(n), C.uint64_t(seed), cColumns, cFilter, &resultIPCData, &resultIPCLen)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to sample table: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to sample table: unknown error")
+	}
+	if resultIPCData == nil || resultIPCLen == 0 {
+		return nil, fmt.Errorf("failed to sample table: empty result")
+	}
+
+	// Guard against integer truncation for payloads > 2GB
+	if resultIPCLen > C.size_t(math.MaxInt32) {
+		C.simple_lancedb_free_ipc_data(resultIPCData)
+		return nil, fmt.Errorf("sample result too large (%d bytes) to copy", resultIPCLen)
+	}
+
+	// #nosec G103 - Safe conversion of C memory to Go bytes for Arrow IPC data
+	ipcBytes := C.GoBytes(unsafe.Pointer(resultIPCData), C.int(resultIPCLen))
+	C.simple_lancedb_free_ipc_data(resultIPCData)
+
+	return ipcBytesToRecord(ipcBytes)
+}
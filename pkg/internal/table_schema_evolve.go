@@ -16,6 +16,8 @@ import (
 	"strings"
 	"unsafe"
 
+	"github.com/apache/arrow/go/v17/arrow"
+
 	"github.com/lancedb/lancedb-go/pkg/contracts"
 )
 
@@ -67,30 +69,56 @@ func (t *Table) AddColumns(_ context.Context, transforms []contracts.NewColumnTr
 	return uint64(version), nil
 }
 
-// AlterColumns renames columns and/or toggles their nullability.
-// Each entry must change at least one attribute — alterations with
-// neither rename nor nullable set are rejected as caller bugs (the
-// backend would otherwise produce a no-op commit).
-func (t *Table) AlterColumns(_ context.Context, alterations []contracts.ColumnAlteration) (uint64, error) {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
-
-	if t.closed || t.handle == nil {
+// AlterColumns renames, casts and/or toggles the nullability of
+// columns. Each entry must change at least one attribute — alterations
+// with no rename, nullable or data type set are rejected as caller
+// bugs (the backend would otherwise produce a no-op commit). Casts are
+// checked against the current schema before anything is sent.
+func (t *Table) AlterColumns(ctx context.Context, alterations []contracts.ColumnAlteration) (uint64, error) {
+	if !t.IsOpen() {
 		return 0, fmt.Errorf("table is closed")
 	}
 	if len(alterations) == 0 {
 		return 0, fmt.Errorf("alter_columns: alterations must be non-empty")
 	}
+	var castFields []arrow.Field
 	for i, a := range alterations {
 		if strings.TrimSpace(a.Path) == "" {
 			return 0, fmt.Errorf("alter_columns: alterations[%d].Path is empty", i)
 		}
-		if a.Rename == nil && a.Nullable == nil {
-			return 0, fmt.Errorf("alter_columns: alterations[%d] has no rename or nullable change", i)
+		if a.Rename == nil && a.Nullable == nil && a.DataType == nil {
+			return 0, fmt.Errorf("alter_columns: alterations[%d] has no rename, nullable or data type change", i)
 		}
 		if a.Rename != nil && strings.TrimSpace(*a.Rename) == "" {
 			return 0, fmt.Errorf("alter_columns: alterations[%d].Rename is empty string", i)
 		}
+		if a.DataType != nil {
+			castFields = append(castFields, arrow.Field{Name: a.Path, Type: a.DataType, Nullable: true})
+		}
+	}
+
+	var castsIPC []byte
+	if len(castFields) > 0 {
+		current, err := t.Schema(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for i, a := range alterations {
+			if a.DataType == nil {
+				continue
+			}
+			field, ok := lookupFieldPath(current, a.Path)
+			if !ok {
+				return 0, fmt.Errorf("alter_columns: alterations[%d]: column %q not found", i, a.Path)
+			}
+			if err := ValidateColumnCast(field.Type, a.DataType); err != nil {
+				return 0, fmt.Errorf("alter_columns: alterations[%d]: column %q: %w", i, a.Path, err)
+			}
+		}
+		castsIPC, err = schemaToIPCBytes(arrow.NewSchema(castFields, nil))
+		if err != nil {
+			return 0, fmt.Errorf("alter_columns: encode casts: %w", err)
+		}
 	}
 
 	payload, err := json.Marshal(alterations)
@@ -101,8 +129,21 @@ func (t *Table) AlterColumns(_ context.Context, alterations []contracts.ColumnAl
 	// #nosec G103 - Required for freeing C allocated string memory
 	defer C.free(unsafe.Pointer(cJSON))
 
+	var castsPtr *C.uchar
+	if len(castsIPC) > 0 {
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		castsPtr = (*C.uchar)(unsafe.Pointer(&castsIPC[0]))
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return 0, fmt.Errorf("table is closed")
+	}
+
 	var version C.uint64_t
-	result := C.simple_lancedb_table_alter_columns(t.handle, cJSON, &version)
+	result := C.simple_lancedb_table_alter_columns_v2(t.handle, cJSON, castsPtr, C.size_t(len(castsIPC)), &version)
 	defer C.simple_lancedb_result_free(result)
 
 	if !result.SUCCESS {
@@ -114,6 +155,92 @@ func (t *Table) AlterColumns(_ context.Context, alterations []contracts.ColumnAl
 	return uint64(version), nil
 }
 
+// lookupFieldPath resolves a dotted column path ("a.b") through struct
+// fields of schema.
+func lookupFieldPath(schema *arrow.Schema, path string) (arrow.Field, bool) {
+	parts := strings.Split(path, ".")
+	fields := schema.Fields()
+	var field arrow.Field
+	for i, part := range parts {
+		found := false
+		for _, f := range fields {
+			if f.Name == part {
+				field, found = f, true
+				break
+			}
+		}
+		if !found {
+			return arrow.Field{}, false
+		}
+		if i < len(parts)-1 {
+			st, ok := field.Type.(*arrow.StructType)
+			if !ok {
+				return arrow.Field{}, false
+			}
+			fields = st.Fields()
+		}
+	}
+	return field, true
+}
+
+// ValidateColumnCast reports whether lance can cast a column of type
+// from to type to. See contracts.ColumnAlteration for the allowed set.
+func ValidateColumnCast(from, to arrow.DataType) error {
+	if arrow.TypeEqual(from, to) {
+		return fmt.Errorf("column is already %s", to)
+	}
+	switch {
+	case isFloatType(from) && isFloatType(to):
+		return nil
+	case integerWidening(from, to):
+		return nil
+	}
+	if fromList, ok := from.(*arrow.FixedSizeListType); ok {
+		if toList, ok := to.(*arrow.FixedSizeListType); ok &&
+			fromList.Len() == toList.Len() &&
+			isFloatType(fromList.Elem()) && isFloatType(toList.Elem()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported cast from %s to %s", from, to)
+}
+
+func isFloatType(dt arrow.DataType) bool {
+	switch dt.ID() {
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64:
+		return true
+	}
+	return false
+}
+
+// integerWidening reports whether from → to is a lossless integer
+// widening.
+func integerWidening(from, to arrow.DataType) bool {
+	fromSigned, fromOK := integerSignedness(from)
+	toSigned, toOK := integerSignedness(to)
+	if !fromOK || !toOK {
+		return false
+	}
+	fromBits := from.(arrow.FixedWidthDataType).BitWidth()
+	toBits := to.(arrow.FixedWidthDataType).BitWidth()
+	if fromSigned {
+		return toSigned && toBits > fromBits
+	}
+	return toBits > fromBits
+}
+
+// integerSignedness returns whether dt is signed and whether it is an
+// integer type at all.
+func integerSignedness(dt arrow.DataType) (signed, ok bool) {
+	switch dt.ID() {
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return true, true
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return false, true
+	}
+	return false, false
+}
+
 // DropColumns removes the named columns from the table. The on-disk
 // bytes are reclaimed on the next OptimizeCompact — DropColumns itself
 // only updates the manifest.
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the schema history
+// capability extension.
+var _ contracts.ITableSchemaHistory = (*Table)(nil)
+
+// SchemaAt returns the table schema as of version.
+func (t *Table) SchemaAt(_ context.Context, version uint64) (*arrow.Schema, error) {
+	schema, _, err := t.schemaAt(version)
+	return schema, err
+}
+
+// SchemaHistory returns the schema of every retained version, oldest
+// first, each with the diff from the version before it.
+func (t *Table) SchemaHistory(ctx context.Context) ([]contracts.SchemaVersion, error) {
+	versions, err := t.ListVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+
+	history := make([]contracts.SchemaVersion, 0, len(versions))
+	var prev *arrow.Schema
+	var prevIDs map[string]int32
+	for _, v := range versions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		schema, ids, err := t.schemaAt(v.Version)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, contracts.SchemaVersion{
+			VersionInfo: v,
+			Schema:      schema,
+			Diff:        diffSchemas(prev, prevIDs, schema, ids),
+		})
+		prev, prevIDs = schema, ids
+	}
+	return history, nil
+}
+
+// schemaAt fetches the schema at version together with the lance field
+// ID of each top-level field.
+func (t *Table) schemaAt(version uint64) (*arrow.Schema, map[string]int32, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, nil, fmt.Errorf("table is closed")
+	}
+
+	var schemaIPCData *C.uchar
+	var schemaIPCLen C.size_t
+	var fieldIDsJSON *C.char
+	result := C.simple_lancedb_table_schema_at_ipc(t.handle, C.uint64_t(version),
+		&schemaIPCData, &schemaIPCLen, &fieldIDsJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, nil, fmt.Errorf("failed to get schema at version %d: %s", version, C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, nil, fmt.Errorf("failed to get schema at version %d: unknown error", version)
+	}
+	if schemaIPCData == nil || fieldIDsJSON == nil {
+		return nil, nil, fmt.Errorf("received null schema IPC data")
+	}
+	defer C.simple_lancedb_free_ipc_data(schemaIPCData)
+	idsStr := C.GoString(fieldIDsJSON)
+	C.simple_lancedb_free_string(fieldIDsJSON)
+
+	if schemaIPCLen > C.size_t(math.MaxInt32) {
+		return nil, nil, fmt.Errorf("schema IPC data too large: %d bytes", schemaIPCLen)
+	}
+	// #nosec G103 - Safe conversion of C memory to Go bytes for Arrow IPC data
+	ipcBytes := C.GoBytes(unsafe.Pointer(schemaIPCData), C.int(schemaIPCLen))
+	schema, err := ipcBytesToSchema(ipcBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids map[string]int32
+	if err := json.Unmarshal([]byte(idsStr), &ids); err != nil {
+		return nil, nil, fmt.Errorf("schema_at: failed to parse field IDs JSON: %w", err)
+	}
+	return schema, ids, nil
+}
+
+// diffSchemas compares top-level fields of prev and cur by lance field
+// ID. A nil prev reports every field of cur as added.
+func diffSchemas(prev *arrow.Schema, prevIDs map[string]int32, cur *arrow.Schema, curIDs map[string]int32) contracts.SchemaDiff {
+	var diff contracts.SchemaDiff
+	if prev == nil {
+		diff.Added = append(diff.Added, cur.Fields()...)
+		return diff
+	}
+
+	prevByID := make(map[int32]arrow.Field, len(prev.Fields()))
+	for _, f := range prev.Fields() {
+		prevByID[prevIDs[f.Name]] = f
+	}
+	seen := make(map[int32]bool, len(cur.Fields()))
+	for _, f := range cur.Fields() {
+		id := curIDs[f.Name]
+		seen[id] = true
+		before, ok := prevByID[id]
+		if !ok {
+			diff.Added = append(diff.Added, f)
+			continue
+		}
+		if before.Name != f.Name {
+			diff.Renamed = append(diff.Renamed, contracts.FieldRename{From: before.Name, To: f.Name})
+		}
+		if !arrow.TypeEqual(before.Type, f.Type) || before.Nullable != f.Nullable {
+			diff.Altered = append(diff.Altered, contracts.FieldAlteration{Before: before, After: f})
+		}
+	}
+	for _, f := range prev.Fields() {
+		if !seen[prevIDs[f.Name]] {
+			diff.Dropped = append(diff.Dropped, f)
+		}
+	}
+
+	// A cast rewrites the column under a new field ID; report a drop
+	// and add of the same name as an alteration instead.
+	var added []arrow.Field
+	for _, f := range diff.Added {
+		matched := false
+		for i, d := range diff.Dropped {
+			if d.Name == f.Name {
+				diff.Altered = append(diff.Altered, contracts.FieldAlteration{Before: d, After: f})
+				diff.Dropped = append(diff.Dropped[:i], diff.Dropped[i+1:]...)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			added = append(added, f)
+		}
+	}
+	diff.Added = added
+	return diff
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the storage statistics
+// capability extension.
+var _ contracts.ITableStats = (*Table)(nil)
+
+// Stats computes storage statistics for the checked-out version.
+func (t *Table) Stats(_ context.Context) (*contracts.TableStats, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	var statsJSON *C.char
+	result := C.simple_lancedb_table_stats(t.handle, &statsJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to get table stats: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to get table stats: unknown error")
+	}
+
+	if statsJSON == nil {
+		return nil, fmt.Errorf("failed to get table stats: empty result")
+	}
+	jsonStr := C.GoString(statsJSON)
+	C.simple_lancedb_free_string(statsJSON)
+
+	var stats contracts.TableStats
+	if err := json.Unmarshal([]byte(jsonStr), &stats); err != nil {
+		return nil, fmt.Errorf("stats: failed to parse result JSON: %w", err)
+	}
+	if stats.Indices == nil {
+		stats.Indices = []contracts.IndexSizeStats{}
+	}
+	return &stats, nil
+}
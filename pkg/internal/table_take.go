@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the take capability
+// extension.
+var _ contracts.ITableTake = (*Table)(nil)
+
+// TakeRowIDs fetches rows by `_rowid`, in the order of ids.
+func (t *Table) TakeRowIDs(_ context.Context, ids []uint64, columns []string) (arrow.Record, error) {
+	return t.take(false, ids, columns)
+}
+
+// TakeOffsets fetches rows by offset, in the order of offsets.
+func (t *Table) TakeOffsets(_ context.Context, offsets []uint64) (arrow.Record, error) {
+	return t.take(true, offsets, nil)
+}
+
+// take is the shared body of TakeRowIDs and TakeOffsets. The result
+// always carries the projected schema, even when ids is empty.
+func (t *Table) take(byOffset bool, ids []uint64, columns []string) (arrow.Record, error) {
+	var columnsJSON []byte
+	if len(columns) > 0 {
+		var err error
+		if columnsJSON, err = json.Marshal(columns); err != nil {
+			return nil, fmt.Errorf("failed to marshal columns: %w", err)
+		}
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	var cColumns *C.char
+	if columnsJSON != nil {
+		cColumns = C.CString(string(columnsJSON))
+		// #nosec G103 - Required for freeing C allocated string memory
+		defer C.free(unsafe.Pointer(cColumns))
+	}
+
+	var idsPtr *C.uint64_t
+	if len(ids) > 0 {
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		idsPtr = (*C.uint64_t)(unsafe.Pointer(&ids[0]))
+	}
+
+	var resultIPCData *C.uchar
+	var resultIPCLen C.size_t
+	var result *C.SimpleResult
+	if byOffset {
+		result = C.simple_lancedb_table_take_offsets(t.handle, idsPtr, C.size_t(len(ids)), cColumns, &resultIPCData, &resultIPCLen)
+	} else {
+		result = C.simple_lancedb_table_take_row_ids(t.handle, idsPtr, C.size_t(len(ids)), cColumns, &resultIPCData, &resultIPCLen)
+	}
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to take rows: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to take rows: unknown error")
+	}
+	if resultIPCData == nil || resultIPCLen == 0 {
+		return nil, fmt.Errorf("failed to take rows: empty result")
+	}
+
+	// Guard against integer truncation for payloads > 2GB
+	if resultIPCLen > C.size_t(math.MaxInt32) {
+		C.simple_lancedb_free_ipc_data(resultIPCData)
+		return nil, fmt.Errorf("take result too large (%d bytes) to copy", resultIPCLen)
+	}
+
+	// #nosec G103 - Safe conversion of C memory to Go bytes for Arrow IPC data
+	ipcBytes := C.GoBytes(unsafe.Pointer(resultIPCData), C.int(resultIPCLen))
+	C.simple_lancedb_free_ipc_data(resultIPCData)
+
+	return ipcBytesToRecord(ipcBytes)
+}
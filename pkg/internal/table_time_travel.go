@@ -66,6 +66,9 @@ func (t *Table) Checkout(_ context.Context, version uint64) error {
 	if t.closed || t.handle == nil {
 		return fmt.Errorf("table is closed")
 	}
+	if t.readOnly {
+		return fmt.Errorf("failed to checkout: %w", contracts.ErrReadOnlyTable)
+	}
 
 	result := C.simple_lancedb_table_checkout(t.handle, C.uint64_t(version))
 	defer C.simple_lancedb_result_free(result)
@@ -88,6 +91,9 @@ func (t *Table) CheckoutTag(_ context.Context, tag string) error {
 	if t.closed || t.handle == nil {
 		return fmt.Errorf("table is closed")
 	}
+	if t.readOnly {
+		return fmt.Errorf("failed to checkout tag: %w", contracts.ErrReadOnlyTable)
+	}
 	if tag == "" {
 		return fmt.Errorf("tag name cannot be empty")
 	}
@@ -117,6 +123,9 @@ func (t *Table) CheckoutLatest(_ context.Context) error {
 	if t.closed || t.handle == nil {
 		return fmt.Errorf("table is closed")
 	}
+	if t.readOnly {
+		return fmt.Errorf("failed to checkout latest: %w", contracts.ErrReadOnlyTable)
+	}
 
 	result := C.simple_lancedb_table_checkout_latest(t.handle)
 	defer C.simple_lancedb_result_free(result)
@@ -139,6 +148,9 @@ func (t *Table) Restore(_ context.Context) error {
 	if t.closed || t.handle == nil {
 		return fmt.Errorf("table is closed")
 	}
+	if t.readOnly {
+		return fmt.Errorf("failed to restore: %w", contracts.ErrReadOnlyTable)
+	}
 
 	result := C.simple_lancedb_table_restore(t.handle)
 	defer C.simple_lancedb_result_free(result)
@@ -224,6 +236,9 @@ func (t *Table) TagCreate(_ context.Context, tag string, version uint64) error {
 	if t.closed || t.handle == nil {
 		return fmt.Errorf("table is closed")
 	}
+	if t.readOnly {
+		return fmt.Errorf("failed to create tag: %w", contracts.ErrReadOnlyTable)
+	}
 	if tag == "" {
 		return fmt.Errorf("tag name cannot be empty")
 	}
@@ -252,6 +267,9 @@ func (t *Table) TagDelete(_ context.Context, tag string) error {
 	if t.closed || t.handle == nil {
 		return fmt.Errorf("table is closed")
 	}
+	if t.readOnly {
+		return fmt.Errorf("failed to delete tag: %w", contracts.ErrReadOnlyTable)
+	}
 	if tag == "" {
 		return fmt.Errorf("tag name cannot be empty")
 	}
@@ -281,6 +299,9 @@ func (t *Table) TagUpdate(_ context.Context, tag string, version uint64) error {
 	if t.closed || t.handle == nil {
 		return fmt.Errorf("table is closed")
 	}
+	if t.readOnly {
+		return fmt.Errorf("failed to update tag: %w", contracts.ErrReadOnlyTable)
+	}
 	if tag == "" {
 		return fmt.Errorf("tag name cannot be empty")
 	}
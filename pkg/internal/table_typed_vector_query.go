@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+import (
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/float16"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table satisfies the typed vector query
+// capability. See contracts.ITableTypedVectorQuery for rationale.
+var _ contracts.ITableTypedVectorQuery = (*Table)(nil)
+
+// VectorQueryFloat16 creates a vector query builder with a float16 query vector.
+func (t *Table) VectorQueryFloat16(column string, vector []float16.Num) contracts.IVectorQueryBuilder {
+	var arr arrow.Array
+	if vector != nil {
+		b := array.NewFloat16Builder(memory.NewGoAllocator())
+		defer b.Release()
+		b.AppendValues(vector, nil)
+		arr = b.NewArray()
+	}
+	return t.newVectorQuery(column, arr)
+}
+
+// VectorQueryFloat64 creates a vector query builder with a float64 query vector.
+func (t *Table) VectorQueryFloat64(column string, vector []float64) contracts.IVectorQueryBuilder {
+	var arr arrow.Array
+	if vector != nil {
+		b := array.NewFloat64Builder(memory.NewGoAllocator())
+		defer b.Release()
+		b.AppendValues(vector, nil)
+		arr = b.NewArray()
+	}
+	return t.newVectorQuery(column, arr)
+}
+
+// VectorQueryInt8 creates a vector query builder with an int8 query vector.
+func (t *Table) VectorQueryInt8(column string, vector []int8) contracts.IVectorQueryBuilder {
+	var arr arrow.Array
+	if vector != nil {
+		b := array.NewInt8Builder(memory.NewGoAllocator())
+		defer b.Release()
+		b.AppendValues(vector, nil)
+		arr = b.NewArray()
+	}
+	return t.newVectorQuery(column, arr)
+}
+
+// VectorQueryBinary creates a vector query builder with a packed-bit
+// uint8 query vector, intended for Hamming distance search.
+func (t *Table) VectorQueryBinary(column string, vector []uint8) contracts.IVectorQueryBuilder {
+	var arr arrow.Array
+	if vector != nil {
+		b := array.NewUint8Builder(memory.NewGoAllocator())
+		defer b.Release()
+		b.AppendValues(vector, nil)
+		arr = b.NewArray()
+	}
+	return t.newVectorQuery(column, arr)
+}
+
+// VectorQueryArrow creates a vector query builder around a caller-owned
+// Arrow array. Element type validation is deferred to Execute.
+func (t *Table) VectorQueryArrow(column string, vector arrow.Array) contracts.IVectorQueryBuilder {
+	return t.newVectorQuery(column, vector)
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// Compile-time check that *Table implements the version watch
+// capability extension.
+var _ contracts.ITableWatch = (*Table)(nil)
+
+// defaultWatchInterval is the poll interval used when Watch is called
+// with a non-positive interval.
+const defaultWatchInterval = time.Second
+
+// Watch emits an event for every version committed after the call,
+// polling the dataset's history every interval. A failed poll is
+// reported as an event with Err set and retried on the next tick. The
+// channel is closed when ctx is done or the table is closed.
+func (t *Table) Watch(ctx context.Context, interval time.Duration) <-chan contracts.VersionEvent {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	events := make(chan contracts.VersionEvent)
+
+	go func() {
+		defer close(events)
+
+		// Baseline: the latest version that exists now. Retried on each
+		// tick until it succeeds so a transient error at start-up does
+		// not turn into a replay of the whole history.
+		var since uint64
+		haveBaseline := false
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if !t.IsOpen() {
+				return
+			}
+			var batch []contracts.VersionEvent
+			var err error
+			if !haveBaseline {
+				var latest uint64
+				if latest, err = t.latestVersion(ctx); err == nil {
+					since, haveBaseline = latest, true
+				}
+			} else {
+				batch, err = t.versionEvents(since)
+			}
+			if err != nil {
+				if !t.IsOpen() || ctx.Err() != nil {
+					return
+				}
+				batch = []contracts.VersionEvent{{Err: fmt.Errorf("watch: %w", err)}}
+			}
+			for _, ev := range batch {
+				select {
+				case events <- ev:
+					if ev.Err == nil {
+						since = ev.Version
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// latestVersion returns the highest version in the table's history.
+func (t *Table) latestVersion(ctx context.Context) (uint64, error) {
+	versions, err := t.ListVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var latest uint64
+	for _, v := range versions {
+		if v.Version > latest {
+			latest = v.Version
+		}
+	}
+	return latest, nil
+}
+
+// versionEvents returns the versions committed after since, oldest
+// first, read from the latest manifest rather than the handle's pin.
+func (t *Table) versionEvents(since uint64) ([]contracts.VersionEvent, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return nil, fmt.Errorf("table is closed")
+	}
+
+	var eventsJSON *C.char
+	result := C.simple_lancedb_table_version_events(t.handle, C.uint64_t(since), &eventsJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to list version events: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to list version events: unknown error")
+	}
+
+	if eventsJSON == nil {
+		return nil, nil
+	}
+	jsonStr := C.GoString(eventsJSON)
+	C.simple_lancedb_free_string(eventsJSON)
+
+	var events []contracts.VersionEvent
+	if err := json.Unmarshal([]byte(jsonStr), &events); err != nil {
+		return nil, fmt.Errorf("version_events: failed to parse result JSON: %w", err)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Version < events[j].Version })
+	return events, nil
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package internal
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unsafe"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+)
+
+// defaultMaxRowsPerFile matches lance's WriteParams default.
+const defaultMaxRowsPerFile = 1024 * 1024
+
+// Compile-time check that *Table implements the fragment commit
+// capability extension.
+var _ contracts.ITableCommitFragments = (*Table)(nil)
+
+type writeFragmentsOptions struct {
+	StorageOptions map[string]string `json:"storage_options,omitempty"`
+	MaxRowsPerFile int               `json:"max_rows_per_file,omitempty"`
+}
+
+// WriteFragments writes the records of reader as uncommitted fragments
+// of the table at uri. Records are handed to lance in chunks of about
+// MaxRowsPerFile rows, so memory use is bounded by one chunk rather
+// than by the whole reader.
+func WriteFragments(ctx context.Context, uri string, reader array.RecordReader, opts *contracts.WriteFragmentsOptions) ([]contracts.FragmentMetadata, error) {
+	if uri == "" {
+		return nil, fmt.Errorf("write_fragments: uri is empty")
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("write_fragments: reader is nil")
+	}
+	if opts == nil {
+		opts = &contracts.WriteFragmentsOptions{}
+	}
+	if opts.MaxRowsPerFile < 0 {
+		return nil, fmt.Errorf("write_fragments: MaxRowsPerFile must not be negative")
+	}
+	optionsJSON, err := json.Marshal(writeFragmentsOptions{
+		StorageOptions: opts.StorageOptions,
+		MaxRowsPerFile: opts.MaxRowsPerFile,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal write options: %w", err)
+	}
+	chunkRows := int64(opts.MaxRowsPerFile)
+	if chunkRows == 0 {
+		chunkRows = defaultMaxRowsPerFile
+	}
+
+	var fragments []contracts.FragmentMetadata
+	var pending []arrow.Record
+	var pendingRows int64
+	release := func() {
+		for _, r := range pending {
+			r.Release()
+		}
+		pending, pendingRows = nil, 0
+	}
+	defer release()
+
+	flush := func() error {
+		if pendingRows == 0 {
+			release()
+			return nil
+		}
+		written, err := writeFragmentChunk(uri, pending, optionsJSON)
+		release()
+		if err != nil {
+			return err
+		}
+		fragments = append(fragments, written...)
+		return nil
+	}
+
+	for reader.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		rec := reader.Record()
+		rec.Retain()
+		pending = append(pending, rec)
+		pendingRows += rec.NumRows()
+		if pendingRows >= chunkRows {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return nil, fmt.Errorf("write_fragments: read input: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return fragments, nil
+}
+
+func writeFragmentChunk(uri string, records []arrow.Record, optionsJSON []byte) ([]contracts.FragmentMetadata, error) {
+	ipcBytes, err := recordsToIPCBytes(records)
+	if err != nil {
+		return nil, err
+	}
+	if len(ipcBytes) == 0 {
+		return nil, fmt.Errorf("no IPC data generated")
+	}
+
+	cURI := C.CString(uri)
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cURI))
+	cOptions := C.CString(string(optionsJSON))
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cOptions))
+
+	var fragmentsJSON *C.char
+	result := C.simple_lancedb_write_fragments(cURI,
+		// #nosec G103 - Safe conversion of Go slice to C array pointer for FFI
+		(*C.uchar)(unsafe.Pointer(&ipcBytes[0])), C.size_t(len(ipcBytes)),
+		cOptions, &fragmentsJSON)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return nil, fmt.Errorf("failed to write fragments: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return nil, fmt.Errorf("failed to write fragments: unknown error")
+	}
+	if fragmentsJSON == nil {
+		return nil, fmt.Errorf("failed to write fragments: empty result")
+	}
+	jsonStr := C.GoString(fragmentsJSON)
+	C.simple_lancedb_free_string(fragmentsJSON)
+
+	var fragments []contracts.FragmentMetadata
+	if err := json.Unmarshal([]byte(jsonStr), &fragments); err != nil {
+		return nil, fmt.Errorf("write_fragments: failed to parse result JSON: %w", err)
+	}
+	return fragments, nil
+}
+
+// CommitFragments appends fragments written by WriteFragments in one
+// commit.
+func (t *Table) CommitFragments(_ context.Context, fragments []contracts.FragmentMetadata) (uint64, error) {
+	if len(fragments) == 0 {
+		return 0, fmt.Errorf("commit_fragments: fragments must be non-empty")
+	}
+	fragmentsJSON, err := json.Marshal(fragments)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal fragments: %w", err)
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.closed || t.handle == nil {
+		return 0, fmt.Errorf("table is closed")
+	}
+	if t.readOnly {
+		return 0, fmt.Errorf("failed to commit fragments: %w", contracts.ErrReadOnlyTable)
+	}
+
+	cFragments := C.CString(string(fragmentsJSON))
+	// #nosec G103 - Required for freeing C allocated string memory
+	defer C.free(unsafe.Pointer(cFragments))
+
+	var version C.uint64_t
+	result := C.simple_lancedb_table_commit_fragments(t.handle, cFragments, &version)
+	defer C.simple_lancedb_result_free(result)
+
+	if !result.SUCCESS {
+		if result.ERROR_MESSAGE != nil {
+			return 0, fmt.Errorf("failed to commit fragments: %s", C.GoString(result.ERROR_MESSAGE))
+		}
+		return 0, fmt.Errorf("failed to commit fragments: unknown error")
+	}
+	return uint64(version), nil
+}
@@ -0,0 +1,34 @@
+package lancedb
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../include
+#include "lancedb.h"
+*/
+import "C"
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v17/arrow/array"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+)
+
+// WriteFragments writes the records of reader as data files of the
+// existing table at uri (the table's directory, e.g.
+// "s3://bucket/db/items.lance") without committing them. It needs no
+// connection, so any number of processes can write in parallel; pass
+// the returned fragments to ITableCommitFragments.CommitFragments on
+// one coordinator to make them visible atomically.
+func WriteFragments(ctx context.Context, uri string, reader array.RecordReader) ([]contracts.FragmentMetadata, error) {
+	return WriteFragmentsWithOptions(ctx, uri, reader, nil)
+}
+
+// WriteFragmentsWithOptions is WriteFragments with storage options and
+// a per-file row cap. A nil opts behaves like WriteFragments.
+func WriteFragmentsWithOptions(ctx context.Context, uri string, reader array.RecordReader,
+	opts *contracts.WriteFragmentsOptions) ([]contracts.FragmentMetadata, error) {
+	initOnce.Do(func() { C.simple_lancedb_init() })
+	return internal.WriteFragments(ctx, uri, reader, opts)
+}
@@ -0,0 +1,377 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+// Package migrate applies declarative schema changes to a LanceDB
+// table. Diff compares a desired contracts.ISchema with the table's
+// current schema and produces an ordered Plan of AlterColumns,
+// AddColumns / AddNullColumns and DropColumns steps; Apply executes it
+// between two tags so the migration can be rolled back with
+// ITableRestore.RestoreTag.
+//
+//	plan, err := migrate.Diff(ctx, table, desired, &migrate.Options{
+//	    Renames:  map[string]string{"name": "label"},
+//	    Defaults: map[string]string{"score_x2": "score * 2"},
+//	})
+//	fmt.Print(plan) // dry run
+//	res, err := migrate.Apply(ctx, table, plan, nil)
+//
+// Only top-level columns are compared, and column order is not: lance
+// appends added columns at the end of the schema.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/arrow/go/v17/arrow"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+)
+
+// StepKind names the table call a Step runs.
+type StepKind string
+
+const (
+	StepAlterColumns   StepKind = "alter_columns"
+	StepAddColumns     StepKind = "add_columns"
+	StepAddNullColumns StepKind = "add_null_columns"
+	StepDropColumns    StepKind = "drop_columns"
+)
+
+// Step is one schema-evolution call. Only the field matching Kind is
+// set.
+type Step struct {
+	Kind        StepKind
+	Alterations []contracts.ColumnAlteration
+	Transforms  []contracts.NewColumnTransform
+	NullFields  []arrow.Field
+	Drops       []string
+}
+
+// Plan is an ordered list of steps that turns the table's schema at
+// FromVersion into Desired. Steps run in order: alterations (renames,
+// casts, nullability), then added columns, then drops, so an added
+// column's default expression may still read a column that is being
+// dropped.
+type Plan struct {
+	Table       string
+	FromVersion uint64
+	Desired     *arrow.Schema
+	Steps       []Step
+}
+
+// IsEmpty reports whether the table already matches the desired
+// schema.
+func (p *Plan) IsEmpty() bool { return len(p.Steps) == 0 }
+
+// String renders the plan for dry-run review, one line per column
+// change.
+func (p *Plan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "migrate table %q from version %d", p.Table, p.FromVersion)
+	if p.IsEmpty() {
+		b.WriteString(": no changes\n")
+		return b.String()
+	}
+	b.WriteString(":\n")
+	n := 0
+	line := func(format string, args ...interface{}) {
+		n++
+		fmt.Fprintf(&b, "  %d. %s\n", n, fmt.Sprintf(format, args...))
+	}
+	for _, s := range p.Steps {
+		switch s.Kind {
+		case StepAlterColumns:
+			for _, a := range s.Alterations {
+				var changes []string
+				if a.Rename != nil {
+					changes = append(changes, "rename to "+*a.Rename)
+				}
+				if a.DataType != nil {
+					changes = append(changes, "cast to "+a.DataType.String())
+				}
+				if a.Nullable != nil {
+					if *a.Nullable {
+						changes = append(changes, "make nullable")
+					} else {
+						changes = append(changes, "make non-nullable")
+					}
+				}
+				line("alter %s: %s", a.Path, strings.Join(changes, ", "))
+			}
+		case StepAddColumns:
+			for _, t := range s.Transforms {
+				line("add %s = %s", t.Name, t.Expression)
+			}
+		case StepAddNullColumns:
+			for _, f := range s.NullFields {
+				line("add %s %s (null)", f.Name, f.Type)
+			}
+		case StepDropColumns:
+			for _, d := range s.Drops {
+				line("drop %s", d)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Options tunes Diff.
+type Options struct {
+	// Renames maps current column names to their desired names. A
+	// column missing from the desired schema is otherwise dropped and
+	// its replacement added empty.
+	Renames map[string]string
+
+	// Defaults holds SQL expressions, keyed by desired column name,
+	// used to fill added columns from existing rows. The expression
+	// must yield the desired type. Added columns without a default are
+	// added as all-null and must be nullable.
+	Defaults map[string]string
+}
+
+// Diff compares desired with the table's current schema and returns
+// the plan that reconciles them. It fails when a change cannot be
+// expressed: an unsupported cast, a rename onto an existing column, a
+// nullable column made non-nullable (lance cannot prove existing rows
+// hold no nulls), or a non-nullable added column without a default.
+func Diff(ctx context.Context, table contracts.ITable, desired contracts.ISchema, opts *Options) (*Plan, error) {
+	if desired == nil || desired.ToArrowSchema() == nil {
+		return nil, fmt.Errorf("migrate: desired schema is nil")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	current, err := table.Schema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: read schema: %w", err)
+	}
+	version, err := table.Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: read version: %w", err)
+	}
+	want := desired.ToArrowSchema()
+
+	wantByName := make(map[string]arrow.Field, len(want.Fields()))
+	for _, f := range want.Fields() {
+		wantByName[f.Name] = f
+	}
+	currentNames := make(map[string]bool, len(current.Fields()))
+	for _, f := range current.Fields() {
+		currentNames[f.Name] = true
+	}
+	for from, to := range opts.Renames {
+		if !currentNames[from] {
+			return nil, fmt.Errorf("migrate: rename source %q does not exist", from)
+		}
+		if _, ok := wantByName[to]; !ok {
+			return nil, fmt.Errorf("migrate: rename target %q is not in the desired schema", to)
+		}
+		if currentNames[to] && opts.Renames[to] == "" {
+			return nil, fmt.Errorf("migrate: rename target %q already exists", to)
+		}
+	}
+
+	var alterations []contracts.ColumnAlteration
+	var drops []string
+	matched := make(map[string]bool, len(want.Fields()))
+	for _, f := range current.Fields() {
+		target := f.Name
+		if to, ok := opts.Renames[f.Name]; ok {
+			target = to
+		}
+		w, ok := wantByName[target]
+		if !ok {
+			drops = append(drops, f.Name)
+			continue
+		}
+		matched[target] = true
+
+		a := contracts.ColumnAlteration{Path: f.Name}
+		changed := false
+		if target != f.Name {
+			name := target
+			a.Rename = &name
+			changed = true
+		}
+		if !arrow.TypeEqual(f.Type, w.Type) {
+			if err := internal.ValidateColumnCast(f.Type, w.Type); err != nil {
+				return nil, fmt.Errorf("migrate: column %q: %w", f.Name, err)
+			}
+			a.DataType = w.Type
+			changed = true
+		}
+		if f.Nullable && !w.Nullable {
+			return nil, fmt.Errorf("migrate: column %q cannot be made non-nullable", f.Name)
+		}
+		if f.Nullable != w.Nullable {
+			nullable := w.Nullable
+			a.Nullable = &nullable
+			changed = true
+		}
+		if changed {
+			alterations = append(alterations, a)
+		}
+	}
+
+	var transforms []contracts.NewColumnTransform
+	var nullFields []arrow.Field
+	for _, w := range want.Fields() {
+		if matched[w.Name] {
+			continue
+		}
+		if expr, ok := opts.Defaults[w.Name]; ok {
+			transforms = append(transforms, contracts.NewColumnTransform{Name: w.Name, Expression: expr})
+			continue
+		}
+		if !w.Nullable {
+			return nil, fmt.Errorf("migrate: added column %q is not nullable and has no default", w.Name)
+		}
+		nullFields = append(nullFields, w)
+	}
+
+	plan := &Plan{Table: table.Name(), FromVersion: uint64(version), Desired: want}
+	if len(alterations) > 0 {
+		sort.SliceStable(alterations, func(i, j int) bool { return alterations[i].Path < alterations[j].Path })
+		plan.Steps = append(plan.Steps, Step{Kind: StepAlterColumns, Alterations: alterations})
+	}
+	if len(transforms) > 0 {
+		plan.Steps = append(plan.Steps, Step{Kind: StepAddColumns, Transforms: transforms})
+	}
+	if len(nullFields) > 0 {
+		plan.Steps = append(plan.Steps, Step{Kind: StepAddNullColumns, NullFields: nullFields})
+	}
+	if len(drops) > 0 {
+		plan.Steps = append(plan.Steps, Step{Kind: StepDropColumns, Drops: drops})
+	}
+	return plan, nil
+}
+
+// ApplyOptions tunes Apply.
+type ApplyOptions struct {
+	// DryRun returns the result without touching the table.
+	DryRun bool
+
+	// TagPrefix names the before/after tags
+	// "<prefix>-<from>-before" and "<prefix>-<from>-after". Defaults
+	// to "migrate".
+	TagPrefix string
+}
+
+// Result reports what Apply did. BeforeTag points at FromVersion and
+// is set as soon as the migration starts, so it is available for
+// RestoreTag even when a later step fails. AfterTag is empty unless
+// every step succeeded and the resulting schema matches the plan.
+type Result struct {
+	Plan        *Plan
+	FromVersion uint64
+	ToVersion   uint64
+	BeforeTag   string
+	AfterTag    string
+}
+
+// Apply runs plan against table. The table must still be at
+// plan.FromVersion. An empty plan is a no-op that creates no tags.
+func Apply(ctx context.Context, table contracts.ITable, plan *Plan, opts *ApplyOptions) (*Result, error) {
+	if plan == nil {
+		return nil, fmt.Errorf("migrate: plan is nil")
+	}
+	if opts == nil {
+		opts = &ApplyOptions{}
+	}
+	prefix := opts.TagPrefix
+	if prefix == "" {
+		prefix = "migrate"
+	}
+	res := &Result{Plan: plan, FromVersion: plan.FromVersion, ToVersion: plan.FromVersion}
+	if opts.DryRun || plan.IsEmpty() {
+		return res, nil
+	}
+
+	se, ok := table.(contracts.ITableSchemaEvolve)
+	if !ok {
+		return nil, fmt.Errorf("migrate: table does not support schema evolution")
+	}
+	tt, ok := table.(contracts.ITableTimeTravel)
+	if !ok {
+		return nil, fmt.Errorf("migrate: table does not support tags")
+	}
+	version, err := table.Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: read version: %w", err)
+	}
+	if uint64(version) != plan.FromVersion {
+		return nil, &contracts.VersionConflictError{Expected: plan.FromVersion, Actual: uint64(version)}
+	}
+
+	before := fmt.Sprintf("%s-%d-before", prefix, plan.FromVersion)
+	if err := tt.TagCreate(ctx, before, plan.FromVersion); err != nil {
+		return nil, fmt.Errorf("migrate: create tag %s: %w", before, err)
+	}
+	res.BeforeTag = before
+
+	for _, s := range plan.Steps {
+		var v uint64
+		var err error
+		switch s.Kind {
+		case StepAlterColumns:
+			v, err = se.AlterColumns(ctx, s.Alterations)
+		case StepAddColumns:
+			v, err = se.AddColumns(ctx, s.Transforms)
+		case StepAddNullColumns:
+			nc, ok := table.(contracts.ITableNullColumns)
+			if !ok {
+				return res, fmt.Errorf("migrate: table does not support null columns")
+			}
+			v, err = nc.AddNullColumns(ctx, s.NullFields)
+		case StepDropColumns:
+			v, err = se.DropColumns(ctx, s.Drops)
+		default:
+			err = fmt.Errorf("unknown step kind %q", s.Kind)
+		}
+		if err != nil {
+			return res, fmt.Errorf("migrate: %s (roll back with tag %s): %w", s.Kind, before, err)
+		}
+		res.ToVersion = v
+	}
+
+	current, err := table.Schema(ctx)
+	if err != nil {
+		return res, fmt.Errorf("migrate: read schema: %w", err)
+	}
+	if err := matchSchema(current, plan.Desired); err != nil {
+		return res, fmt.Errorf("migrate: result does not match the desired schema (roll back with tag %s): %w", before, err)
+	}
+
+	after := fmt.Sprintf("%s-%d-after", prefix, plan.FromVersion)
+	if err := tt.TagCreate(ctx, after, res.ToVersion); err != nil {
+		return res, fmt.Errorf("migrate: create tag %s: %w", after, err)
+	}
+	res.AfterTag = after
+	return res, nil
+}
+
+// matchSchema checks that got has exactly the fields of want, ignoring
+// order.
+func matchSchema(got, want *arrow.Schema) error {
+	if len(got.Fields()) != len(want.Fields()) {
+		return fmt.Errorf("got %d columns, want %d", len(got.Fields()), len(want.Fields()))
+	}
+	for _, w := range want.Fields() {
+		fields, ok := got.FieldsByName(w.Name)
+		if !ok {
+			return fmt.Errorf("column %q is missing", w.Name)
+		}
+		g := fields[0]
+		if !arrow.TypeEqual(g.Type, w.Type) {
+			return fmt.Errorf("column %q is %s, want %s", w.Name, g.Type, w.Type)
+		}
+		if g.Nullable != w.Nullable {
+			return fmt.Errorf("column %q nullable = %t, want %t", w.Name, g.Nullable, w.Nullable)
+		}
+	}
+	return nil
+}
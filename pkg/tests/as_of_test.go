@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestAsOf exercises read-only snapshot handles. The key property is
+// that reading history through AsOf / AsOfVersion never moves the pin
+// of the originating handle.
+func TestAsOf(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_as_of_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	// seed creates a table with two rows, records that version, then
+	// appends a third row. Returns the table and the two-row version.
+	seed := func(t *testing.T, name string) (contracts.ITable, uint64) {
+		t.Helper()
+		ctx := context.Background()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{1, 2}, []string{"Alice", "Bob"}, []float64{10, 20})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		v, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+
+		rec2 := buildRecord(t, pool, arrowSchema, []int32{3}, []string{"Carol"}, []float64{30})
+		defer rec2.Release()
+		if err := table.Add(ctx, rec2, nil); err != nil {
+			t.Fatalf("second add: %v", err)
+		}
+		return table, uint64(v)
+	}
+
+	asOf := func(t *testing.T, table contracts.ITable) contracts.ITableAsOf {
+		t.Helper()
+		ao, ok := table.(contracts.ITableAsOf)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableAsOf")
+		}
+		return ao
+	}
+
+	t.Run("VersionSnapshotLeavesOriginalAlone", func(t *testing.T) {
+		ctx := context.Background()
+		table, v := seed(t, "as_of_version")
+
+		snap, err := asOf(t, table).AsOf(ctx, contracts.AtVersion(v))
+		if err != nil {
+			t.Fatalf("AsOf: %v", err)
+		}
+		defer snap.Close()
+
+		if n, err := snap.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("snapshot Count = %d, %v; want 2", n, err)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 3 {
+			t.Fatalf("original Count = %d, %v; want 3", n, err)
+		}
+
+		// Writes through the original handle still succeed and are not
+		// visible through the snapshot.
+		rec := buildRecord(t, pool, arrowSchema, []int32{4}, []string{"Dan"}, []float64{40})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("Add on original: %v", err)
+		}
+		if n, err := snap.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("snapshot Count after write = %d, %v; want 2", n, err)
+		}
+	})
+
+	t.Run("TagSnapshot", func(t *testing.T) {
+		ctx := context.Background()
+		table, v := seed(t, "as_of_tag")
+		tt := table.(contracts.ITableTimeTravel)
+		if err := tt.TagCreate(ctx, "two_rows", v); err != nil {
+			t.Fatalf("TagCreate: %v", err)
+		}
+
+		snap, err := asOf(t, table).AsOf(ctx, contracts.AtTag("two_rows"))
+		if err != nil {
+			t.Fatalf("AsOf: %v", err)
+		}
+		defer snap.Close()
+
+		if n, err := snap.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("snapshot Count = %d, %v; want 2", n, err)
+		}
+	})
+
+	t.Run("SnapshotIsReadOnly", func(t *testing.T) {
+		ctx := context.Background()
+		table, v := seed(t, "as_of_read_only")
+
+		snap, err := asOf(t, table).AsOf(ctx, contracts.AtVersion(v))
+		if err != nil {
+			t.Fatalf("AsOf: %v", err)
+		}
+		defer snap.Close()
+
+		err = snap.(contracts.ITableTimeTravel).CheckoutLatest(ctx)
+		if !errors.Is(err, contracts.ErrReadOnlyTable) {
+			t.Fatalf("CheckoutLatest on snapshot: got %v, want ErrReadOnlyTable", err)
+		}
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{9}, []string{"Zed"}, []float64{90})
+		defer rec.Release()
+		if err := snap.Add(ctx, rec, nil); err == nil {
+			t.Fatalf("Add on snapshot should fail")
+		}
+	})
+
+	t.Run("InvalidRef", func(t *testing.T) {
+		ctx := context.Background()
+		table, _ := seed(t, "as_of_invalid")
+		ao := asOf(t, table)
+
+		if _, err := ao.AsOf(ctx, contracts.VersionRef{}); err == nil {
+			t.Fatalf("empty ref should fail")
+		}
+		if _, err := ao.AsOf(ctx, contracts.VersionRef{Version: 1, Tag: "x"}); err == nil {
+			t.Fatalf("ref with both version and tag should fail")
+		}
+		if _, err := ao.AsOf(ctx, contracts.AtTag("missing")); err == nil {
+			t.Fatalf("unknown tag should fail")
+		}
+	})
+
+	t.Run("QueryAsOfVersion", func(t *testing.T) {
+		ctx := context.Background()
+		table, v := seed(t, "as_of_query")
+
+		rec, err := table.Query().AsOfVersion(v).Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		defer rec.Release()
+		if rec.NumRows() != 2 {
+			t.Fatalf("historical query returned %d rows, want 2", rec.NumRows())
+		}
+
+		cur, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		if uint64(cur) == v {
+			t.Fatalf("AsOfVersion moved the original handle to version %d", v)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestAddColumnsFromFunc exercises Go-computed column backfill, its
+// output validation, and resuming from a checkpoint after a failure.
+func TestAddColumnsFromFunc(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_backfill_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	outputSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "name_len", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	}, nil)
+
+	// seed creates a table with three fragments (one Add each) holding
+	// ids 1..6.
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableBackfill) {
+		t.Helper()
+		ctx := context.Background()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		batches := [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee", "ffffff"}}
+		for i, names := range batches {
+			ids := []int32{int32(2*i + 1), int32(2*i + 2)}
+			rec := buildRecord(t, pool, arrowSchema, ids, names, []float64{1, 2})
+			err := table.Add(ctx, rec, nil)
+			rec.Release()
+			if err != nil {
+				t.Fatalf("seed add: %v", err)
+			}
+		}
+		bf, ok := table.(contracts.ITableBackfill)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableBackfill")
+		}
+		return table, bf
+	}
+
+	// nameLen computes len(name) for each row.
+	nameLen := func(batch arrow.Record) (arrow.Record, error) {
+		names := batch.Column(0).(*array.String)
+		b := array.NewInt32Builder(pool)
+		defer b.Release()
+		for i := 0; i < names.Len(); i++ {
+			b.Append(int32(len(names.Value(i))))
+		}
+		col := b.NewArray()
+		defer col.Release()
+		return array.NewRecord(outputSchema, []arrow.Array{col}, int64(col.Len())), nil
+	}
+
+	// verify checks name_len == len(name) on every row.
+	verify := func(t *testing.T, table contracts.ITable) {
+		t.Helper()
+		rows, err := table.Select(context.Background(), contracts.QueryConfig{
+			Columns: []string{"name", "name_len"},
+		})
+		if err != nil {
+			t.Fatalf("Select: %v", err)
+		}
+		if len(rows) != 6 {
+			t.Fatalf("Select returned %d rows, want 6", len(rows))
+		}
+		for _, r := range rows {
+			name, _ := r["name"].(string)
+			// Select decodes JSON, so numbers arrive as float64.
+			if got, want := r["name_len"], float64(len(name)); got != want {
+				t.Errorf("name %q: name_len = %v, want %v", name, got, want)
+			}
+		}
+	}
+
+	t.Run("Basic", func(t *testing.T) {
+		table, bf := seed(t, "backfill_basic")
+		before, _ := table.Version(context.Background())
+		v, err := bf.AddColumnsFromFunc(context.Background(), outputSchema, []string{"name"}, nameLen)
+		if err != nil {
+			t.Fatalf("AddColumnsFromFunc: %v", err)
+		}
+		if v != uint64(before)+1 {
+			t.Fatalf("new version = %d, want %d", v, before+1)
+		}
+		verify(t, table)
+	})
+
+	t.Run("ResumeFromCheckpoint", func(t *testing.T) {
+		table, bf := seed(t, "backfill_resume")
+		ckptPath := filepath.Join(tempDir, "resume.ckpt")
+		opts := &contracts.AddColumnsFromFuncOptions{CheckpointPath: ckptPath}
+		errCrash := errors.New("simulated crash")
+
+		calls := 0
+		crashing := func(batch arrow.Record) (arrow.Record, error) {
+			calls++
+			if calls == 2 {
+				return nil, errCrash
+			}
+			return nameLen(batch)
+		}
+		if _, err := bf.AddColumnsFromFuncWithOptions(context.Background(), outputSchema, []string{"name"}, crashing, opts); !errors.Is(err, errCrash) {
+			t.Fatalf("first run: got %v, want simulated crash", err)
+		}
+		if _, err := os.Stat(ckptPath); err != nil {
+			t.Fatalf("checkpoint not written: %v", err)
+		}
+		if hasColumn(t, table, "name_len") {
+			t.Fatalf("failed backfill committed the column")
+		}
+
+		resumed := 0
+		counting := func(batch arrow.Record) (arrow.Record, error) {
+			resumed++
+			return nameLen(batch)
+		}
+		if _, err := bf.AddColumnsFromFuncWithOptions(context.Background(), outputSchema, []string{"name"}, counting, opts); err != nil {
+			t.Fatalf("resumed run: %v", err)
+		}
+		if resumed != 2 {
+			t.Fatalf("resumed run processed %d fragments, want 2 (first was checkpointed)", resumed)
+		}
+		if _, err := os.Stat(ckptPath); !os.IsNotExist(err) {
+			t.Fatalf("checkpoint not removed after commit: %v", err)
+		}
+		verify(t, table)
+	})
+
+	t.Run("DeletedRows", func(t *testing.T) {
+		table, bf := seed(t, "backfill_deleted")
+		ctx := context.Background()
+		// Empties the first fragment and thins the second.
+		if err := table.Delete(ctx, "id IN (1, 2, 3)"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := bf.AddColumnsFromFunc(ctx, outputSchema, []string{"name"}, nameLen); err != nil {
+			t.Fatalf("AddColumnsFromFunc: %v", err)
+		}
+		rows, err := table.Select(ctx, contracts.QueryConfig{Columns: []string{"name", "name_len"}})
+		if err != nil {
+			t.Fatalf("Select: %v", err)
+		}
+		if len(rows) != 3 {
+			t.Fatalf("Select returned %d rows, want 3", len(rows))
+		}
+		for _, r := range rows {
+			name, _ := r["name"].(string)
+			if got, want := r["name_len"], float64(len(name)); got != want {
+				t.Errorf("name %q: name_len = %v, want %v", name, got, want)
+			}
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		table, bf := seed(t, "backfill_validation")
+		ctx := context.Background()
+
+		clash := arrow.NewSchema([]arrow.Field{{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true}}, nil)
+		if _, err := bf.AddColumnsFromFunc(ctx, clash, []string{"name"}, nameLen); err == nil {
+			t.Errorf("existing column name should be rejected")
+		}
+		if _, err := bf.AddColumnsFromFunc(ctx, outputSchema, []string{"missing"}, nameLen); err == nil {
+			t.Errorf("unknown read column should be rejected")
+		}
+		short := func(batch arrow.Record) (arrow.Record, error) {
+			rec, err := nameLen(batch)
+			if err != nil {
+				return nil, err
+			}
+			defer rec.Release()
+			return rec.NewSlice(0, rec.NumRows()-1), nil
+		}
+		if _, err := bf.AddColumnsFromFunc(ctx, outputSchema, []string{"name"}, short); err == nil {
+			t.Errorf("row count mismatch should be rejected")
+		}
+		if hasColumn(t, table, "name_len") {
+			t.Fatalf("rejected backfill committed the column")
+		}
+	})
+}
+
+func hasColumn(t *testing.T, table contracts.ITable, name string) bool {
+	t.Helper()
+	s, err := table.Schema(context.Background())
+	if err != nil {
+		t.Fatalf("Schema: %v", err)
+	}
+	_, ok := s.FieldsByName(name)
+	return ok
+}
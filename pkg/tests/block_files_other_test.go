@@ -0,0 +1,16 @@
+//go:build !unix
+
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import "testing"
+
+// blockDataFiles needs FIFOs, which this platform lacks; the calling
+// test is skipped.
+func blockDataFiles(t *testing.T, _ string) func() {
+	t.Helper()
+	t.Skip("blocking data files requires FIFOs")
+	return func() {}
+}
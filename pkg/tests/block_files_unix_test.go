@@ -0,0 +1,41 @@
+//go:build unix
+
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// blockDataFiles replaces every file in dir with a FIFO of the same
+// name, so opening one for reading blocks until a writer appears. The
+// returned release opens each FIFO as a writer and closes it again,
+// which lets blocked readers through to EOF.
+func blockDataFiles(t *testing.T, dir string) func() {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no data files in %s: %v", dir, err)
+	}
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil {
+			t.Fatalf("remove %s: %v", p, err)
+		}
+		if err := syscall.Mkfifo(p, 0o600); err != nil {
+			t.Fatalf("mkfifo %s: %v", p, err)
+		}
+	}
+	return func() {
+		for _, p := range paths {
+			// O_RDWR does not wait for a reader, unlike O_WRONLY.
+			if f, err := os.OpenFile(p, os.O_RDWR, 0); err == nil {
+				_ = f.Close()
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestBranches exercises the branch lifecycle: cut, list, write on the
+// branch without touching main, then delete.
+func TestBranches(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_branches_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	// seed creates a table with two rows and returns it with its
+	// branch capability and the two-row version.
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableBranches, uint64) {
+		t.Helper()
+		ctx := context.Background()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{1, 2}, []string{"Alice", "Bob"}, []float64{10, 20})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		v, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		br, ok := table.(contracts.ITableBranches)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableBranches")
+		}
+		return table, br, uint64(v)
+	}
+
+	t.Run("WritesStayOnBranch", func(t *testing.T) {
+		ctx := context.Background()
+		table, br, v := seed(t, "branch_writes")
+
+		if err := br.BranchCreate(ctx, "experiment", contracts.AtVersion(v)); err != nil {
+			t.Fatalf("BranchCreate: %v", err)
+		}
+
+		branches, err := br.BranchList(ctx)
+		if err != nil {
+			t.Fatalf("BranchList: %v", err)
+		}
+		info, ok := branches["experiment"]
+		if !ok {
+			t.Fatalf("BranchList missing experiment: %v", branches)
+		}
+		if info.ParentVersion != v {
+			t.Fatalf("ParentVersion = %d, want %d", info.ParentVersion, v)
+		}
+
+		staged, err := br.CheckoutBranch(ctx, "experiment")
+		if err != nil {
+			t.Fatalf("CheckoutBranch: %v", err)
+		}
+		defer staged.Close()
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{3}, []string{"Carol"}, []float64{30})
+		defer rec.Release()
+		if err := staged.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("Add on branch: %v", err)
+		}
+
+		if n, err := staged.Count(ctx); err != nil || n != 3 {
+			t.Fatalf("branch Count = %d, %v; want 3", n, err)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("main Count = %d, %v; want 2", n, err)
+		}
+
+		// A delete through the branch handle, and a fresh handle on main
+		// that has no cached state, must both leave main as seeded.
+		if err := staged.Delete(ctx, "id = 1"); err != nil {
+			t.Fatalf("Delete on branch: %v", err)
+		}
+		if n, err := staged.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("branch Count after delete = %d, %v; want 2", n, err)
+		}
+		fresh, err := conn.OpenTable(ctx, "branch_writes")
+		if err != nil {
+			t.Fatalf("OpenTable: %v", err)
+		}
+		defer fresh.Close()
+		if n, err := fresh.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("reopened main Count = %d, %v; want 2", n, err)
+		}
+		if got, err := fresh.Version(ctx); err != nil || uint64(got) != v {
+			t.Fatalf("reopened main Version = %d, %v; want %d", got, err, v)
+		}
+	})
+
+	t.Run("CreateFromTag", func(t *testing.T) {
+		ctx := context.Background()
+		table, br, v := seed(t, "branch_from_tag")
+		if err := table.(contracts.ITableTimeTravel).TagCreate(ctx, "base", v); err != nil {
+			t.Fatalf("TagCreate: %v", err)
+		}
+		if err := br.BranchCreate(ctx, "from_tag", contracts.AtTag("base")); err != nil {
+			t.Fatalf("BranchCreate: %v", err)
+		}
+		branches, err := br.BranchList(ctx)
+		if err != nil {
+			t.Fatalf("BranchList: %v", err)
+		}
+		if got := branches["from_tag"].ParentVersion; got != v {
+			t.Fatalf("ParentVersion = %d, want %d", got, v)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		ctx := context.Background()
+		_, br, v := seed(t, "branch_delete")
+
+		if err := br.BranchCreate(ctx, "doomed", contracts.AtVersion(v)); err != nil {
+			t.Fatalf("BranchCreate: %v", err)
+		}
+		if err := br.BranchDelete(ctx, "doomed"); err != nil {
+			t.Fatalf("BranchDelete: %v", err)
+		}
+		branches, err := br.BranchList(ctx)
+		if err != nil {
+			t.Fatalf("BranchList: %v", err)
+		}
+		if _, ok := branches["doomed"]; ok {
+			t.Fatalf("deleted branch still listed")
+		}
+		if _, err := br.CheckoutBranch(ctx, "doomed"); err == nil {
+			t.Fatalf("CheckoutBranch of deleted branch should fail")
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		ctx := context.Background()
+		_, br, v := seed(t, "branch_validation")
+
+		if err := br.BranchCreate(ctx, "", contracts.AtVersion(v)); err == nil {
+			t.Fatalf("empty branch name should fail")
+		}
+		if err := br.BranchCreate(ctx, "dup", contracts.AtVersion(v)); err != nil {
+			t.Fatalf("BranchCreate: %v", err)
+		}
+		if err := br.BranchCreate(ctx, "dup", contracts.AtVersion(v)); err == nil {
+			t.Fatalf("duplicate branch should fail")
+		}
+	})
+}
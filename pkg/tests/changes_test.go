@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestChanges exercises the change data feed over a table created with
+// stable row IDs: one insert, one update and one delete after a
+// baseline version must each surface exactly once with the right
+// change type.
+func TestChanges(t *testing.T) {
+	ctx := context.Background()
+	tempDir, err := os.MkdirTemp("", "lancedb_test_changes_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(ctx, tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	pool := memory.NewGoAllocator()
+
+	co, ok := conn.(contracts.IConnectionCreateTableOptions)
+	if !ok {
+		t.Fatalf("connection does not implement contracts.IConnectionCreateTableOptions")
+	}
+	table, err := co.CreateTableWithOptions(ctx, "changes", schema,
+		contracts.CreateTableOptions{EnableStableRowIDs: true})
+	if err != nil {
+		t.Fatalf("CreateTableWithOptions: %v", err)
+	}
+	defer table.Close()
+
+	rec := buildRecord(t, pool, arrowSchema, []int32{1, 2, 3}, []string{"Alice", "Bob", "Carol"}, []float64{10, 20, 30})
+	defer rec.Release()
+	if err := table.Add(ctx, rec, nil); err != nil {
+		t.Fatalf("seed add: %v", err)
+	}
+	base, err := table.Version(ctx)
+	if err != nil {
+		t.Fatalf("Version: %v", err)
+	}
+
+	if err := table.Update(ctx, "id = 2", map[string]interface{}{"score": 25.0}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := table.Delete(ctx, "id = 3"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	rec2 := buildRecord(t, pool, arrowSchema, []int32{4}, []string{"Dan"}, []float64{40})
+	defer rec2.Release()
+	if err := table.Add(ctx, rec2, nil); err != nil {
+		t.Fatalf("second add: %v", err)
+	}
+	head, err := table.Version(ctx)
+	if err != nil {
+		t.Fatalf("Version: %v", err)
+	}
+
+	ch, ok := table.(contracts.ITableChanges)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableChanges")
+	}
+
+	t.Run("InsertUpdateDelete", func(t *testing.T) {
+		rr, err := ch.Changes(ctx, uint64(base), uint64(head))
+		if err != nil {
+			t.Fatalf("Changes: %v", err)
+		}
+		defer rr.Release()
+
+		got := map[int32]string{}
+		for rr.Next() {
+			r := rr.Record()
+			ids := r.Column(r.Schema().FieldIndices("id")[0]).(*array.Int32)
+			kinds := r.Column(r.Schema().FieldIndices(contracts.ChangeTypeColumn)[0]).(*array.String)
+			versions := r.Column(r.Schema().FieldIndices(contracts.ChangeVersionColumn)[0]).(*array.Uint64)
+			for i := 0; i < int(r.NumRows()); i++ {
+				got[ids.Value(i)] = kinds.Value(i)
+				if kinds.Value(i) == contracts.ChangeTypeDelete {
+					if !versions.IsNull(i) {
+						t.Fatalf("delete row %d has non-null change version", ids.Value(i))
+					}
+				} else if v := versions.Value(i); v <= uint64(base) || v > uint64(head) {
+					t.Fatalf("row %d change version %d outside (%d, %d]", ids.Value(i), v, base, head)
+				}
+			}
+		}
+		if err := rr.Err(); err != nil {
+			t.Fatalf("reader: %v", err)
+		}
+
+		want := map[int32]string{
+			2: contracts.ChangeTypeUpdate,
+			3: contracts.ChangeTypeDelete,
+			4: contracts.ChangeTypeInsert,
+		}
+		if len(got) != len(want) {
+			t.Fatalf("changes = %v, want %v", got, want)
+		}
+		for id, kind := range want {
+			if got[id] != kind {
+				t.Fatalf("row %d change = %q, want %q (all: %v)", id, got[id], kind, got)
+			}
+		}
+	})
+
+	t.Run("ChangeColumnsPresent", func(t *testing.T) {
+		rr, err := ch.Changes(ctx, uint64(head)-1, uint64(head))
+		if err != nil {
+			t.Fatalf("Changes: %v", err)
+		}
+		defer rr.Release()
+		if rr.Schema().FieldIndices(contracts.ChangeTypeColumn) == nil {
+			t.Fatalf("feed must carry the change columns")
+		}
+	})
+
+	t.Run("RequiresStableRowIDs", func(t *testing.T) {
+		plain, err := conn.CreateTable(ctx, "changes_plain", schema)
+		if err != nil {
+			t.Fatalf("CreateTable: %v", err)
+		}
+		defer plain.Close()
+		for _, r := range []arrow.Record{rec, rec2} {
+			if err := plain.Add(ctx, r, nil); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		v, err := plain.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		if _, err := plain.(contracts.ITableChanges).Changes(ctx, uint64(v)-1, uint64(v)); err == nil {
+			t.Fatalf("a table without stable row IDs should be rejected")
+		}
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		if _, err := ch.Changes(ctx, uint64(head), uint64(base)); err == nil {
+			t.Fatalf("reversed range should fail")
+		}
+		if _, err := ch.Changes(ctx, 0, uint64(head)); err == nil {
+			t.Fatalf("fromVersion 0 should fail")
+		}
+	})
+
+	// Runs last: compaction drops every fragment and rewrites the live
+	// rows under their old row IDs, so the range holds no deletes even
+	// though the fragments are gone. A delete after it still shows.
+	t.Run("CompactionIsNotADelete", func(t *testing.T) {
+		if _, err := table.OptimizeWithAction(ctx, contracts.OptimizeAction{Kind: contracts.OptimizeCompact}); err != nil {
+			t.Fatalf("compact: %v", err)
+		}
+		if err := table.Delete(ctx, "id = 1"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		latest, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		rr, err := ch.Changes(ctx, uint64(head), uint64(latest))
+		if err != nil {
+			t.Fatalf("Changes: %v", err)
+		}
+		defer rr.Release()
+		var deleted []int32
+		for rr.Next() {
+			r := rr.Record()
+			ids := r.Column(r.Schema().FieldIndices("id")[0]).(*array.Int32)
+			kinds := r.Column(r.Schema().FieldIndices(contracts.ChangeTypeColumn)[0]).(*array.String)
+			for i := 0; i < int(r.NumRows()); i++ {
+				if kinds.Value(i) == contracts.ChangeTypeDelete {
+					deleted = append(deleted, ids.Value(i))
+				}
+			}
+		}
+		if err := rr.Err(); err != nil {
+			t.Fatalf("reader: %v", err)
+		}
+		if len(deleted) != 1 || deleted[0] != 1 {
+			t.Fatalf("deleted ids = %v, want [1]", deleted)
+		}
+	})
+}
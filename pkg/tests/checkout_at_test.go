@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestCheckoutAt exercises timestamp-based version resolution. Each
+// sub-test seeds two versions separated by a pause so "mid" falls
+// unambiguously between their commit timestamps.
+func TestCheckoutAt(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_checkout_at_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	const pause = 50 * time.Millisecond
+
+	// seed returns the table, the version holding one row, and an
+	// instant after that version but before the next commit.
+	seed := func(t *testing.T, name string) (contracts.ITable, uint64, time.Time) {
+		t.Helper()
+		ctx := context.Background()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{1}, []string{"Alice"}, []float64{10})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("first add: %v", err)
+		}
+		v, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+
+		time.Sleep(pause)
+		mid := time.Now()
+		time.Sleep(pause)
+
+		rec2 := buildRecord(t, pool, arrowSchema, []int32{2}, []string{"Bob"}, []float64{20})
+		defer rec2.Release()
+		if err := table.Add(ctx, rec2, nil); err != nil {
+			t.Fatalf("second add: %v", err)
+		}
+		return table, uint64(v), mid
+	}
+
+	checkoutAt := func(t *testing.T, table contracts.ITable) contracts.ITableCheckoutAt {
+		t.Helper()
+		ca, ok := table.(contracts.ITableCheckoutAt)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableCheckoutAt")
+		}
+		return ca
+	}
+
+	t.Run("CheckoutAtResolvesEarlierVersion", func(t *testing.T) {
+		ctx := context.Background()
+		table, v, mid := seed(t, "checkout_at_basic")
+
+		if err := checkoutAt(t, table).CheckoutAt(ctx, mid); err != nil {
+			t.Fatalf("CheckoutAt: %v", err)
+		}
+		got, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		if uint64(got) != v {
+			t.Fatalf("CheckoutAt pinned version %d, want %d", got, v)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 1 {
+			t.Fatalf("Count = %d, %v; want 1", n, err)
+		}
+	})
+
+	t.Run("AsOfTime", func(t *testing.T) {
+		ctx := context.Background()
+		table, _, mid := seed(t, "checkout_at_as_of")
+
+		snap, err := table.(contracts.ITableAsOf).AsOf(ctx, contracts.AtTime(mid))
+		if err != nil {
+			t.Fatalf("AsOf: %v", err)
+		}
+		defer snap.Close()
+
+		if n, err := snap.Count(ctx); err != nil || n != 1 {
+			t.Fatalf("snapshot Count = %d, %v; want 1", n, err)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("original Count = %d, %v; want 2", n, err)
+		}
+	})
+
+	t.Run("BeforeCreationIsNotPruned", func(t *testing.T) {
+		ctx := context.Background()
+		table, _, _ := seed(t, "checkout_at_before_create")
+
+		_, err := checkoutAt(t, table).ResolveVersionAt(ctx, time.Now().Add(-time.Hour))
+		if err == nil {
+			t.Fatalf("expected error for instant before table creation")
+		}
+		var pruned *contracts.VersionPrunedError
+		if errors.As(err, &pruned) {
+			t.Fatalf("instant before creation reported as pruned: %v", err)
+		}
+	})
+
+	t.Run("PrunedVersion", func(t *testing.T) {
+		ctx := context.Background()
+		table, _, mid := seed(t, "checkout_at_pruned")
+
+		time.Sleep(pause)
+		deleteUnverified := true
+		if _, err := table.OptimizeWithAction(ctx, contracts.OptimizeAction{
+			Kind: contracts.OptimizePrune,
+			Prune: contracts.PruneParams{
+				OlderThan:        time.Millisecond,
+				DeleteUnverified: &deleteUnverified,
+			},
+		}); err != nil {
+			t.Fatalf("prune: %v", err)
+		}
+
+		err := checkoutAt(t, table).CheckoutAt(ctx, mid)
+		var pruned *contracts.VersionPrunedError
+		if !errors.As(err, &pruned) {
+			t.Fatalf("CheckoutAt after prune: got %v, want *VersionPrunedError", err)
+		}
+		if pruned.Oldest <= 1 {
+			t.Fatalf("pruned error reports oldest version %d", pruned.Oldest)
+		}
+	})
+
+	t.Run("PrunedMiddleRange", func(t *testing.T) {
+		ctx := context.Background()
+		table, v, mid := seed(t, "checkout_at_pruned_middle")
+
+		// Keep the empty version 1 with a tag and commit once more, so
+		// cleanup leaves versions 1 and latest with v and v+1 gone.
+		if err := table.(contracts.ITableTimeTravel).TagCreate(ctx, "created", 1); err != nil {
+			t.Fatalf("TagCreate: %v", err)
+		}
+		rec := buildRecord(t, pool, arrowSchema, []int32{3}, []string{"Carol"}, []float64{30})
+		err := table.Add(ctx, rec, nil)
+		rec.Release()
+		if err != nil {
+			t.Fatalf("third add: %v", err)
+		}
+		time.Sleep(pause)
+		deleteUnverified, errorIfTagged := true, false
+		if _, err := table.OptimizeWithAction(ctx, contracts.OptimizeAction{
+			Kind: contracts.OptimizePrune,
+			Prune: contracts.PruneParams{
+				OlderThan:                time.Millisecond,
+				DeleteUnverified:         &deleteUnverified,
+				ErrorIfTaggedOldVersions: &errorIfTagged,
+			},
+		}); err != nil {
+			t.Fatalf("prune: %v", err)
+		}
+		versions, err := table.(contracts.ITableTimeTravel).ListVersions(ctx)
+		if err != nil {
+			t.Fatalf("ListVersions: %v", err)
+		}
+		for _, kept := range versions {
+			if kept.Version == v {
+				t.Fatalf("version %d survived cleanup: %v", v, versions)
+			}
+		}
+
+		_, err = checkoutAt(t, table).ResolveVersionAt(ctx, mid)
+		var pruned *contracts.VersionPrunedError
+		if !errors.As(err, &pruned) {
+			t.Fatalf("ResolveVersionAt over a pruned range: got %v, want *VersionPrunedError", err)
+		}
+		if pruned.Oldest <= v {
+			t.Fatalf("pruned error reports version %d, want one after %d", pruned.Oldest, v)
+		}
+	})
+}
@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+const castVectorDim = 4
+
+// TestColumnCast exercises ColumnAlteration.DataType for each supported
+// cast and the Go-side rejection of unsupported ones.
+func TestColumnCast(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_column_cast_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "vec", Type: arrow.FixedSizeListOf(castVectorDim, arrow.PrimitiveTypes.Float32), Nullable: false},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	// seed creates a 3-row table where row i has id = i, score = i + 0.5
+	// and vec = [i, i, i, i].
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableSchemaEvolve) {
+		t.Helper()
+		ctx := context.Background()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		idB := array.NewInt32Builder(pool)
+		defer idB.Release()
+		scoreB := array.NewFloat64Builder(pool)
+		defer scoreB.Release()
+		vecB := array.NewFixedSizeListBuilder(pool, castVectorDim, arrow.PrimitiveTypes.Float32)
+		defer vecB.Release()
+		vecValues := vecB.ValueBuilder().(*array.Float32Builder)
+		for i := 0; i < 3; i++ {
+			idB.Append(int32(i))
+			scoreB.Append(float64(i) + 0.5)
+			vecB.Append(true)
+			for j := 0; j < castVectorDim; j++ {
+				vecValues.Append(float32(i))
+			}
+		}
+		ids, scores, vecs := idB.NewArray(), scoreB.NewArray(), vecB.NewArray()
+		defer ids.Release()
+		defer scores.Release()
+		defer vecs.Release()
+		rec := array.NewRecord(arrowSchema, []arrow.Array{ids, scores, vecs}, 3)
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		return table, table.(contracts.ITableSchemaEvolve)
+	}
+
+	// column reads the named column of the whole table.
+	column := func(t *testing.T, table contracts.ITable, name string) arrow.Array {
+		t.Helper()
+		rec, err := table.Query().Columns([]string{name}).Execute(context.Background())
+		if err != nil {
+			t.Fatalf("query %s: %v", name, err)
+		}
+		t.Cleanup(rec.Release)
+		if rec.NumRows() != 3 {
+			t.Fatalf("query returned %d rows, want 3", rec.NumRows())
+		}
+		return rec.Column(0)
+	}
+
+	fieldType := func(t *testing.T, table contracts.ITable, name string) arrow.DataType {
+		t.Helper()
+		s, err := table.Schema(context.Background())
+		if err != nil {
+			t.Fatalf("Schema: %v", err)
+		}
+		fields, ok := s.FieldsByName(name)
+		if !ok {
+			t.Fatalf("field %q not found", name)
+		}
+		return fields[0].Type
+	}
+
+	t.Run("Int32ToInt64", func(t *testing.T) {
+		table, se := seed(t, "cast_int32_int64")
+		if _, err := se.AlterColumns(context.Background(), []contracts.ColumnAlteration{
+			{Path: "id", DataType: arrow.PrimitiveTypes.Int64},
+		}); err != nil {
+			t.Fatalf("AlterColumns: %v", err)
+		}
+		if got := fieldType(t, table, "id"); !arrow.TypeEqual(got, arrow.PrimitiveTypes.Int64) {
+			t.Fatalf("id type = %s, want int64", got)
+		}
+		var sum int64
+		ids := column(t, table, "id").(*array.Int64)
+		for i := 0; i < ids.Len(); i++ {
+			sum += ids.Value(i)
+		}
+		if sum != 3 {
+			t.Fatalf("sum of ids = %d, want 3", sum)
+		}
+	})
+
+	t.Run("Float64ToFloat32", func(t *testing.T) {
+		table, se := seed(t, "cast_float64_float32")
+		if _, err := se.AlterColumns(context.Background(), []contracts.ColumnAlteration{
+			{Path: "score", DataType: arrow.PrimitiveTypes.Float32},
+		}); err != nil {
+			t.Fatalf("AlterColumns: %v", err)
+		}
+		if got := fieldType(t, table, "score"); !arrow.TypeEqual(got, arrow.PrimitiveTypes.Float32) {
+			t.Fatalf("score type = %s, want float32", got)
+		}
+		scores := column(t, table, "score").(*array.Float32)
+		var sum float32
+		for i := 0; i < scores.Len(); i++ {
+			sum += scores.Value(i)
+		}
+		if sum != 4.5 {
+			t.Fatalf("sum of scores = %v, want 4.5", sum)
+		}
+	})
+
+	t.Run("VectorFloat32ToFloat16AndBack", func(t *testing.T) {
+		table, se := seed(t, "cast_vector_float16")
+		f16Type := arrow.FixedSizeListOf(castVectorDim, arrow.FixedWidthTypes.Float16)
+		if _, err := se.AlterColumns(context.Background(), []contracts.ColumnAlteration{
+			{Path: "vec", DataType: f16Type},
+		}); err != nil {
+			t.Fatalf("AlterColumns to float16: %v", err)
+		}
+		if got := fieldType(t, table, "vec"); !arrow.TypeEqual(got, f16Type) {
+			t.Fatalf("vec type = %s, want %s", got, f16Type)
+		}
+		vecs := column(t, table, "vec").(*array.FixedSizeList)
+		values := vecs.ListValues().(*array.Float16)
+		var sum float32
+		for i := 0; i < values.Len(); i++ {
+			sum += values.Value(i).Float32()
+		}
+		if want := float32(castVectorDim * (0 + 1 + 2)); sum != want {
+			t.Fatalf("sum of vector values = %v, want %v", sum, want)
+		}
+
+		f32Type := arrow.FixedSizeListOf(castVectorDim, arrow.PrimitiveTypes.Float32)
+		if _, err := se.AlterColumns(context.Background(), []contracts.ColumnAlteration{
+			{Path: "vec", DataType: f32Type},
+		}); err != nil {
+			t.Fatalf("AlterColumns back to float32: %v", err)
+		}
+		if got := fieldType(t, table, "vec"); !arrow.TypeEqual(got, f32Type) {
+			t.Fatalf("vec type = %s, want %s", got, f32Type)
+		}
+	})
+
+	t.Run("AllowedCasts", func(t *testing.T) {
+		i8, i16, i32, i64 := arrow.PrimitiveTypes.Int8, arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64
+		u8, u16, u32, u64 := arrow.PrimitiveTypes.Uint8, arrow.PrimitiveTypes.Uint16, arrow.PrimitiveTypes.Uint32, arrow.PrimitiveTypes.Uint64
+		f16, f32, f64 := arrow.FixedWidthTypes.Float16, arrow.PrimitiveTypes.Float32, arrow.PrimitiveTypes.Float64
+		vec := func(elem arrow.DataType) arrow.DataType { return arrow.FixedSizeListOf(castVectorDim, elem) }
+
+		cases := []struct {
+			name     string
+			from, to arrow.DataType
+		}{
+			{"Int8ToInt16", i8, i16},
+			{"Int8ToInt32", i8, i32},
+			{"Int8ToInt64", i8, i64},
+			{"Int16ToInt32", i16, i32},
+			{"Int16ToInt64", i16, i64},
+			{"Int32ToInt64", i32, i64},
+			{"Uint8ToUint16", u8, u16},
+			{"Uint8ToUint32", u8, u32},
+			{"Uint8ToUint64", u8, u64},
+			{"Uint8ToInt16", u8, i16},
+			{"Uint8ToInt32", u8, i32},
+			{"Uint8ToInt64", u8, i64},
+			{"Uint16ToUint32", u16, u32},
+			{"Uint16ToUint64", u16, u64},
+			{"Uint16ToInt32", u16, i32},
+			{"Uint16ToInt64", u16, i64},
+			{"Uint32ToUint64", u32, u64},
+			{"Uint32ToInt64", u32, i64},
+			{"Float16ToFloat32", f16, f32},
+			{"Float16ToFloat64", f16, f64},
+			{"Float32ToFloat16", f32, f16},
+			{"Float32ToFloat64", f32, f64},
+			{"Float64ToFloat16", f64, f16},
+			{"Float64ToFloat32", f64, f32},
+			{"VectorFloat16ToFloat32", vec(f16), vec(f32)},
+			{"VectorFloat16ToFloat64", vec(f16), vec(f64)},
+			{"VectorFloat32ToFloat16", vec(f32), vec(f16)},
+			{"VectorFloat32ToFloat64", vec(f32), vec(f64)},
+			{"VectorFloat64ToFloat16", vec(f64), vec(f16)},
+			{"VectorFloat64ToFloat32", vec(f64), vec(f32)},
+		}
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				if err := internal.ValidateColumnCast(c.from, c.to); err != nil {
+					t.Fatalf("ValidateColumnCast: %v", err)
+				}
+
+				// Small integral values survive every cast exactly, so
+				// the result must equal the same JSON read as c.to.
+				values := `[1, 2, 3]`
+				if _, ok := c.from.(*arrow.FixedSizeListType); ok {
+					values = `[[1, 1, 1, 1], [2, 2, 2, 2], [3, 3, 3, 3]]`
+				}
+				castSchema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: c.from, Nullable: false}}, nil)
+				s, err := internal.NewSchema(castSchema)
+				if err != nil {
+					t.Fatalf("failed to create schema: %v", err)
+				}
+				ctx := context.Background()
+				table, err := conn.CreateTable(ctx, "cast_"+strings.ToLower(c.name), s)
+				if err != nil {
+					t.Fatalf("create table: %v", err)
+				}
+				t.Cleanup(func() { _ = table.Close() })
+
+				col, _, err := array.FromJSON(pool, c.from, strings.NewReader(values))
+				if err != nil {
+					t.Fatalf("FromJSON: %v", err)
+				}
+				defer col.Release()
+				rec := array.NewRecord(castSchema, []arrow.Array{col}, int64(col.Len()))
+				defer rec.Release()
+				if err := table.Add(ctx, rec, nil); err != nil {
+					t.Fatalf("seed add: %v", err)
+				}
+
+				if _, err := table.(contracts.ITableSchemaEvolve).AlterColumns(ctx, []contracts.ColumnAlteration{
+					{Path: "v", DataType: c.to},
+				}); err != nil {
+					t.Fatalf("AlterColumns: %v", err)
+				}
+				if got := fieldType(t, table, "v"); !arrow.TypeEqual(got, c.to) {
+					t.Fatalf("v type = %s, want %s", got, c.to)
+				}
+				want, _, err := array.FromJSON(pool, c.to, strings.NewReader(values))
+				if err != nil {
+					t.Fatalf("FromJSON: %v", err)
+				}
+				defer want.Release()
+				if got := column(t, table, "v"); !array.Equal(got, want) {
+					t.Fatalf("v = %v, want %v", got, want)
+				}
+			})
+		}
+	})
+
+	t.Run("CastWithRename", func(t *testing.T) {
+		table, se := seed(t, "cast_with_rename")
+		newName := "id64"
+		if _, err := se.AlterColumns(context.Background(), []contracts.ColumnAlteration{
+			{Path: "id", Rename: &newName, DataType: arrow.PrimitiveTypes.Int64},
+		}); err != nil {
+			t.Fatalf("AlterColumns: %v", err)
+		}
+		if got := fieldType(t, table, "id64"); !arrow.TypeEqual(got, arrow.PrimitiveTypes.Int64) {
+			t.Fatalf("id64 type = %s, want int64", got)
+		}
+	})
+
+	t.Run("RejectedCasts", func(t *testing.T) {
+		table, se := seed(t, "cast_rejected")
+		before, err := table.Version(context.Background())
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		cases := []contracts.ColumnAlteration{
+			{Path: "id", DataType: arrow.PrimitiveTypes.Int16},                                            // narrowing
+			{Path: "id", DataType: arrow.PrimitiveTypes.Int32},                                            // no-op
+			{Path: "id", DataType: arrow.BinaryTypes.String},                                              // not numeric
+			{Path: "score", DataType: arrow.PrimitiveTypes.Int64},                                         // float to int
+			{Path: "vec", DataType: arrow.FixedSizeListOf(castVectorDim*2, arrow.PrimitiveTypes.Float32)}, // dimension change
+			{Path: "missing", DataType: arrow.PrimitiveTypes.Int64},                                       // unknown column
+		}
+		for _, c := range cases {
+			if _, err := se.AlterColumns(context.Background(), []contracts.ColumnAlteration{c}); err == nil {
+				t.Errorf("cast of %s to %s should be rejected", c.Path, c.DataType)
+			}
+		}
+		after, err := table.Version(context.Background())
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		if after != before {
+			t.Fatalf("rejected casts committed versions: %d -> %d", before, after)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestCountWhere counts filtered rows through ITableCountWhere and the
+// query builder's Count terminal.
+func TestCountWhere(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_count_where_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	table, err := conn.CreateTable(ctx, "count_where", schema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	defer table.Close()
+
+	ids := make([]int32, 100)
+	names := make([]string, 100)
+	scores := make([]float64, 100)
+	for i := range ids {
+		ids[i] = int32(i)
+		names[i] = "even"
+		if i%2 == 1 {
+			names[i] = "odd"
+		}
+		scores[i] = float64(i)
+	}
+	rec := buildRecord(t, pool, arrowSchema, ids, names, scores)
+	err = table.Add(ctx, rec, nil)
+	rec.Release()
+	if err != nil {
+		t.Fatalf("seed add: %v", err)
+	}
+	if err := table.CreateIndex(ctx, []string{"id"}, contracts.IndexTypeBTree); err != nil {
+		t.Fatalf("CreateIndex: %v", err)
+	}
+
+	cw, ok := table.(contracts.ITableCountWhere)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableCountWhere")
+	}
+
+	t.Run("CountWhere", func(t *testing.T) {
+		cases := []struct {
+			filter string
+			want   int64
+		}{
+			{"", 100},
+			{"id < 10", 10},
+			{"name = 'odd'", 50},
+			{"id >= 90 AND name = 'even'", 5},
+			{"id > 1000", 0},
+		}
+		for _, c := range cases {
+			n, err := cw.CountWhere(ctx, c.filter)
+			if err != nil {
+				t.Fatalf("CountWhere(%q): %v", c.filter, err)
+			}
+			if n != c.want {
+				t.Errorf("CountWhere(%q) = %d, want %d", c.filter, n, c.want)
+			}
+		}
+		if _, err := cw.CountWhere(ctx, "missing_column = 1"); err == nil {
+			t.Errorf("unknown column should fail")
+		}
+	})
+
+	t.Run("QueryCount", func(t *testing.T) {
+		n, err := table.Query().Filter("score >= 50").Filter("name = 'odd'").Count(ctx)
+		if err != nil || n != 25 {
+			t.Fatalf("Count = %d, %v; want 25", n, err)
+		}
+		if n, err := table.Query().Filter("id < 10").Offset(4).Limit(3).Count(ctx); err != nil || n != 3 {
+			t.Fatalf("Count with offset/limit = %d, %v; want 3", n, err)
+		}
+		if n, err := table.Query().Filter("id < 10").Offset(20).Count(ctx); err != nil || n != 0 {
+			t.Fatalf("Count past the end = %d, %v; want 0", n, err)
+		}
+		if _, err := table.Query().Fragments(0).Count(ctx); err == nil {
+			t.Errorf("Count with Fragments should be rejected")
+		}
+	})
+
+	t.Run("AsOfVersion", func(t *testing.T) {
+		v, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		if err := table.Delete(ctx, "id < 50"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if n, err := table.Query().Filter("name = 'odd'").Count(ctx); err != nil || n != 25 {
+			t.Fatalf("Count after delete = %d, %v; want 25", n, err)
+		}
+		n, err := table.Query().Filter("name = 'odd'").AsOfVersion(uint64(v)).Count(ctx)
+		if err != nil || n != 50 {
+			t.Fatalf("Count at version %d = %d, %v; want 50", v, n, err)
+		}
+	})
+}
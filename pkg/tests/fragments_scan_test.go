@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestFragmentScan lists fragments and scans them in parallel with a
+// filter and projection, checking the union matches a full scan.
+func TestFragmentScan(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_fragment_scan_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	table, err := conn.CreateTable(ctx, "fragment_scan", schema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	defer table.Close()
+
+	// Four fragments of 5 rows each: ids 0..19.
+	for f := int32(0); f < 4; f++ {
+		ids := make([]int32, 5)
+		names := make([]string, 5)
+		scores := make([]float64, 5)
+		for i := range ids {
+			ids[i] = f*5 + int32(i)
+			names[i] = "row"
+			scores[i] = float64(ids[i])
+		}
+		rec := buildRecord(t, pool, arrowSchema, ids, names, scores)
+		err := table.Add(ctx, rec, nil)
+		rec.Release()
+		if err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+	}
+	if err := table.Delete(ctx, "id = 7"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	tf, ok := table.(contracts.ITableFragments)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableFragments")
+	}
+	frags, err := tf.Fragments(ctx)
+	if err != nil {
+		t.Fatalf("Fragments: %v", err)
+	}
+	if len(frags) != 4 {
+		t.Fatalf("Fragments returned %d, want 4", len(frags))
+	}
+	var total uint64
+	for _, f := range frags {
+		total += f.NumRows
+		if f.PhysicalRows != 5 || f.NumRows+f.NumDeletedRows != f.PhysicalRows {
+			t.Errorf("fragment %d = %+v", f.ID, f)
+		}
+	}
+	if total != 19 {
+		t.Fatalf("fragment row total = %d, want 19", total)
+	}
+
+	ids := func(t *testing.T, rec arrow.Record) []int32 {
+		t.Helper()
+		if rec == nil {
+			return nil
+		}
+		defer rec.Release()
+		if rec.NumCols() != 1 {
+			t.Fatalf("projection returned %d columns, want 1", rec.NumCols())
+		}
+		col := rec.Column(0).(*array.Int32)
+		out := make([]int32, col.Len())
+		for i := range out {
+			out[i] = col.Value(i)
+		}
+		return out
+	}
+
+	t.Run("ParallelUnion", func(t *testing.T) {
+		recs := make([]arrow.Record, len(frags))
+		errs := make([]error, len(frags))
+		var wg sync.WaitGroup
+		for i, f := range frags {
+			wg.Add(1)
+			go func(i int, id uint64) {
+				defer wg.Done()
+				recs[i], errs[i] = table.Query().Fragments(id).Filter("score >= 3").Columns([]string{"id"}).Execute(ctx)
+			}(i, f.ID)
+		}
+		wg.Wait()
+		var got []int32
+		for i := range frags {
+			if errs[i] != nil {
+				t.Fatalf("fragment %d scan: %v", frags[i].ID, errs[i])
+			}
+			got = append(got, ids(t, recs[i])...)
+		}
+
+		full, err := table.Query().Filter("score >= 3").Columns([]string{"id"}).Execute(ctx)
+		if err != nil {
+			t.Fatalf("full scan: %v", err)
+		}
+		want := ids(t, full)
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+		if len(got) != len(want) {
+			t.Fatalf("fragment union has %d rows, full scan %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("fragment union %v != full scan %v", got, want)
+			}
+		}
+	})
+
+	t.Run("SingleFragment", func(t *testing.T) {
+		rec, err := table.Query().Fragments(frags[0].ID).Columns([]string{"id"}).Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		if got := ids(t, rec); len(got) != int(frags[0].NumRows) {
+			t.Fatalf("fragment %d returned %d rows, want %d", frags[0].ID, len(got), frags[0].NumRows)
+		}
+	})
+
+	t.Run("NoFragments", func(t *testing.T) {
+		rec, err := table.Query().Fragments().Columns([]string{"id"}).Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		if rec == nil {
+			t.Fatalf("Fragments() returned a nil record, want an empty one with the schema")
+		}
+		defer rec.Release()
+		if rec.NumRows() != 0 || rec.NumCols() != 1 || !rec.Schema().HasField("id") {
+			t.Fatalf("Fragments() = %d rows, schema %v; want 0 rows of id", rec.NumRows(), rec.Schema())
+		}
+
+		page, next, err := table.Query().Fragments().Limit(5).ExecutePage(ctx)
+		if err != nil {
+			t.Fatalf("ExecutePage: %v", err)
+		}
+		if page == nil {
+			t.Fatalf("ExecutePage with Fragments() returned a nil record")
+		}
+		defer page.Release()
+		if page.NumRows() != 0 || page.NumCols() == 0 || next != "" {
+			t.Fatalf("ExecutePage with Fragments() = %d rows, %d cols, next %q", page.NumRows(), page.NumCols(), next)
+		}
+	})
+
+	t.Run("UnknownFragment", func(t *testing.T) {
+		if _, err := table.Query().Fragments(9999).Execute(ctx); err == nil {
+			t.Fatalf("unknown fragment should be rejected")
+		}
+	})
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+	"github.com/lancedb/lancedb-go/pkg/lancedb/migrate"
+)
+
+// TestMigrate exercises declarative schema migration: planning, dry
+// run, apply with before/after tags, and rejected plans.
+func TestMigrate(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_migrate_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	seed := func(t *testing.T, name string) contracts.ITable {
+		t.Helper()
+		table, err := conn.CreateTable(context.Background(), name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{1, 2, 3}, []string{"Alice", "Bob", "Carol"}, []float64{10, 20, 30})
+		defer rec.Release()
+		if err := table.Add(context.Background(), rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		return table
+	}
+
+	// desired: id widened to int64, name renamed to label, score
+	// dropped, score_x2 filled from score, note added as null.
+	desiredArrow := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: false},
+		{Name: "label", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score_x2", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "note", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	desired, err := internal.NewSchema(desiredArrow)
+	if err != nil {
+		t.Fatalf("failed to create desired schema: %v", err)
+	}
+	diffOpts := &migrate.Options{
+		Renames:  map[string]string{"name": "label"},
+		Defaults: map[string]string{"score_x2": "score * 2"},
+	}
+
+	t.Run("PlanAndDryRun", func(t *testing.T) {
+		ctx := context.Background()
+		table := seed(t, "migrate_plan")
+		before, _ := table.Version(ctx)
+
+		plan, err := migrate.Diff(ctx, table, desired, diffOpts)
+		if err != nil {
+			t.Fatalf("Diff: %v", err)
+		}
+		var kinds []migrate.StepKind
+		for _, s := range plan.Steps {
+			kinds = append(kinds, s.Kind)
+		}
+		want := []migrate.StepKind{migrate.StepAlterColumns, migrate.StepAddColumns, migrate.StepAddNullColumns, migrate.StepDropColumns}
+		if len(kinds) != len(want) {
+			t.Fatalf("steps = %v, want %v", kinds, want)
+		}
+		for i := range want {
+			if kinds[i] != want[i] {
+				t.Fatalf("steps = %v, want %v", kinds, want)
+			}
+		}
+
+		out := plan.String()
+		for _, s := range []string{"alter id: cast to int64", "alter name: rename to label", "add score_x2 = score * 2", "add note utf8 (null)", "drop score"} {
+			if !strings.Contains(out, s) {
+				t.Errorf("dry-run output missing %q:\n%s", s, out)
+			}
+		}
+
+		res, err := migrate.Apply(ctx, table, plan, &migrate.ApplyOptions{DryRun: true})
+		if err != nil {
+			t.Fatalf("dry-run Apply: %v", err)
+		}
+		if res.BeforeTag != "" || res.AfterTag != "" {
+			t.Errorf("dry run created tags: %+v", res)
+		}
+		if after, _ := table.Version(ctx); after != before {
+			t.Fatalf("dry run committed versions: %d -> %d", before, after)
+		}
+	})
+
+	t.Run("ApplyWithTags", func(t *testing.T) {
+		ctx := context.Background()
+		table := seed(t, "migrate_apply")
+
+		plan, err := migrate.Diff(ctx, table, desired, diffOpts)
+		if err != nil {
+			t.Fatalf("Diff: %v", err)
+		}
+		res, err := migrate.Apply(ctx, table, plan, nil)
+		if err != nil {
+			t.Fatalf("Apply: %v", err)
+		}
+		if res.ToVersion <= res.FromVersion {
+			t.Fatalf("ToVersion %d not after FromVersion %d", res.ToVersion, res.FromVersion)
+		}
+
+		tags, err := table.(contracts.ITableTimeTravel).TagList(ctx)
+		if err != nil {
+			t.Fatalf("TagList: %v", err)
+		}
+		for name, version := range map[string]uint64{res.BeforeTag: res.FromVersion, res.AfterTag: res.ToVersion} {
+			if got, ok := tags[name]; !ok || got.Version != version {
+				t.Errorf("tag %q = %+v (present %t), want version %d", name, got, ok, version)
+			}
+		}
+
+		rows, err := table.Select(ctx, contracts.QueryConfig{Columns: []string{"label", "score_x2", "note"}})
+		if err != nil {
+			t.Fatalf("Select: %v", err)
+		}
+		for _, r := range rows {
+			if r["note"] != nil {
+				t.Errorf("note = %v, want null", r["note"])
+			}
+		}
+		if len(rows) != 3 {
+			t.Fatalf("Select returned %d rows, want 3", len(rows))
+		}
+
+		again, err := migrate.Diff(ctx, table, desired, nil)
+		if err != nil {
+			t.Fatalf("second Diff: %v", err)
+		}
+		if !again.IsEmpty() {
+			t.Fatalf("migrated table still differs:\n%s", again)
+		}
+	})
+
+	t.Run("StalePlan", func(t *testing.T) {
+		ctx := context.Background()
+		table := seed(t, "migrate_stale")
+		plan, err := migrate.Diff(ctx, table, desired, diffOpts)
+		if err != nil {
+			t.Fatalf("Diff: %v", err)
+		}
+		rec := buildRecord(t, pool, arrowSchema, []int32{4}, []string{"Dan"}, []float64{40})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if _, err := migrate.Apply(ctx, table, plan, nil); err == nil {
+			t.Fatalf("stale plan should be rejected")
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		ctx := context.Background()
+		table := seed(t, "migrate_rejected")
+
+		cases := map[string]*arrow.Schema{
+			"narrowingCast": arrow.NewSchema([]arrow.Field{
+				{Name: "id", Type: arrow.PrimitiveTypes.Int16, Nullable: false},
+				{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+				{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			}, nil),
+			"nullableToRequired": arrow.NewSchema([]arrow.Field{
+				{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+				{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+				{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: false},
+			}, nil),
+			"requiredWithoutDefault": arrow.NewSchema([]arrow.Field{
+				{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+				{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+				{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+				{Name: "extra", Type: arrow.BinaryTypes.String, Nullable: false},
+			}, nil),
+		}
+		for name, s := range cases {
+			d, err := internal.NewSchema(s)
+			if err != nil {
+				t.Fatalf("%s: NewSchema: %v", name, err)
+			}
+			if _, err := migrate.Diff(ctx, table, d, nil); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
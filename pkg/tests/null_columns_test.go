@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestAddNullColumns exercises metadata-only null columns of vector,
+// struct and scalar types, plus Go-side validation.
+func TestAddNullColumns(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_null_columns_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableNullColumns) {
+		t.Helper()
+		table, err := conn.CreateTable(context.Background(), name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{1, 2, 3}, []string{"Alice", "Bob", "Carol"}, []float64{10, 20, 30})
+		defer rec.Release()
+		if err := table.Add(context.Background(), rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		nc, ok := table.(contracts.ITableNullColumns)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableNullColumns")
+		}
+		return table, nc
+	}
+
+	vectorType := arrow.FixedSizeListOf(8, arrow.PrimitiveTypes.Float32)
+	structType := arrow.StructOf(
+		arrow.Field{Name: "source", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "confidence", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+	)
+
+	t.Run("VectorStructAndScalar", func(t *testing.T) {
+		ctx := context.Background()
+		table, nc := seed(t, "null_columns_types")
+		before, _ := table.Version(ctx)
+
+		v, err := nc.AddNullColumns(ctx, []arrow.Field{
+			{Name: "embedding", Type: vectorType, Nullable: true},
+			{Name: "provenance", Type: structType, Nullable: true},
+			{Name: "lang", Type: arrow.BinaryTypes.String, Nullable: true},
+		})
+		if err != nil {
+			t.Fatalf("AddNullColumns: %v", err)
+		}
+		if v != uint64(before)+1 {
+			t.Fatalf("new version = %d, want %d", v, before+1)
+		}
+
+		s, err := table.Schema(ctx)
+		if err != nil {
+			t.Fatalf("Schema: %v", err)
+		}
+		for name, want := range map[string]arrow.DataType{
+			"embedding":  vectorType,
+			"provenance": structType,
+			"lang":       arrow.BinaryTypes.String,
+		} {
+			fields, ok := s.FieldsByName(name)
+			if !ok {
+				t.Fatalf("column %q missing", name)
+			}
+			if !arrow.TypeEqual(fields[0].Type, want) {
+				t.Errorf("column %q type = %s, want %s", name, fields[0].Type, want)
+			}
+		}
+
+		rec, err := table.Query().Columns([]string{"id", "embedding", "lang"}).Execute(ctx)
+		if err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		defer rec.Release()
+		if rec.NumRows() != 3 {
+			t.Fatalf("query returned %d rows, want 3", rec.NumRows())
+		}
+		for _, col := range []int{1, 2} {
+			if n := rec.Column(col).NullN(); n != 3 {
+				t.Errorf("column %s has %d nulls, want 3", rec.ColumnName(col), n)
+			}
+		}
+
+		if n, err := table.Count(ctx); err != nil || n != 3 {
+			t.Fatalf("Count = %d, %v; want 3", n, err)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		ctx := context.Background()
+		table, nc := seed(t, "null_columns_validation")
+		before, _ := table.Version(ctx)
+
+		cases := map[string][]arrow.Field{
+			"empty":       nil,
+			"notNullable": {{Name: "x", Type: arrow.BinaryTypes.String, Nullable: false}},
+			"exists":      {{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true}},
+			"duplicate": {
+				{Name: "x", Type: arrow.BinaryTypes.String, Nullable: true},
+				{Name: "x", Type: arrow.BinaryTypes.String, Nullable: true},
+			},
+			"noName": {{Type: arrow.BinaryTypes.String, Nullable: true}},
+		}
+		for name, fields := range cases {
+			if _, err := nc.AddNullColumns(ctx, fields); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+		if after, _ := table.Version(ctx); after != before {
+			t.Fatalf("rejected calls committed versions: %d -> %d", before, after)
+		}
+	})
+}
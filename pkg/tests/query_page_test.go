@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestQueryPages walks plain scans and vector searches page by page
+// with ExecutePage cursors.
+func TestQueryPages(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_query_page_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	// Ten rows; the vectors of ids 0-3 are all at distance 1 from the
+	// origin, ids 4-7 at distance 4 and ids 8-9 at distance 9.
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "vec", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Float32), Nullable: false},
+	}, nil)
+	vectors := [][]float32{
+		{1, 0}, {0, 1}, {-1, 0}, {0, -1},
+		{2, 0}, {0, 2}, {-2, 0}, {0, -2},
+		{3, 0}, {0, 3},
+	}
+	table := createTableWithRecord(t, conn, "pages", arrowSchema, func(b *array.RecordBuilder) {
+		vb := b.Field(1).(*array.FixedSizeListBuilder)
+		fb := vb.ValueBuilder().(*array.Float32Builder)
+		for i, v := range vectors {
+			b.Field(0).(*array.Int32Builder).Append(int32(i))
+			vb.Append(true)
+			fb.AppendValues(v, nil)
+		}
+	})
+	defer table.Close()
+
+	// collect returns the ids of a page and whether it has a _rowid column.
+	collect := func(t *testing.T, rec arrow.Record) ([]int32, bool) {
+		t.Helper()
+		if rec == nil {
+			return nil, false
+		}
+		defer rec.Release()
+		idx := rec.Schema().FieldIndices("id")
+		if len(idx) != 1 {
+			t.Fatalf("page has no id column: %v", rec.Schema())
+		}
+		return append([]int32(nil), rec.Column(idx[0]).(*array.Int32).Int32Values()...), rec.Schema().HasField("_rowid")
+	}
+	// exactlyOnce fails unless ids holds 0..n-1 with no repeats.
+	exactlyOnce := func(t *testing.T, ids []int32, n int) {
+		t.Helper()
+		seen := map[int32]bool{}
+		for _, id := range ids {
+			if seen[id] {
+				t.Fatalf("id %d returned twice: %v", id, ids)
+			}
+			seen[id] = true
+		}
+		if len(seen) != n {
+			t.Fatalf("got %d distinct ids, want %d: %v", len(seen), n, ids)
+		}
+	}
+
+	t.Run("Scan", func(t *testing.T) {
+		var all []int32
+		cursor := ""
+		for page := 0; ; page++ {
+			rec, next, err := table.Query().Limit(4).After(cursor).ExecutePage(ctx)
+			if err != nil {
+				t.Fatalf("page %d: %v", page, err)
+			}
+			ids, hasRowID := collect(t, rec)
+			if hasRowID {
+				t.Fatalf("page %d has _rowid without WithRowID", page)
+			}
+			all = append(all, ids...)
+			if next == "" {
+				break
+			}
+			if page > 3 {
+				t.Fatalf("scan did not terminate")
+			}
+			cursor = next
+		}
+		exactlyOnce(t, all, 10)
+		for i, id := range all {
+			if id != int32(i) {
+				t.Fatalf("ids = %v, want storage order", all)
+			}
+		}
+	})
+
+	t.Run("ScanFilteredPinned", func(t *testing.T) {
+		rec, next, err := table.Query().Filter("id >= 2").Limit(3).ExecutePage(ctx)
+		if err != nil || next == "" {
+			t.Fatalf("first page: next=%q err=%v", next, err)
+		}
+		first, _ := collect(t, rec)
+
+		extra := createRows(t, arrowSchema, []int32{100}, [][]float32{{9, 9}})
+		err = table.Add(ctx, extra, nil)
+		extra.Release()
+		if err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		defer func() {
+			if err := table.Delete(ctx, "id = 100"); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+		}()
+
+		all := first
+		for next != "" {
+			rec, next, err = table.Query().Filter("id >= 2").Limit(3).After(next).ExecutePage(ctx)
+			if err != nil {
+				t.Fatalf("page: %v", err)
+			}
+			ids, _ := collect(t, rec)
+			all = append(all, ids...)
+		}
+		if len(all) != 8 {
+			t.Fatalf("ids = %v, want 2..9 from the pinned version", all)
+		}
+	})
+
+	t.Run("Vector", func(t *testing.T) {
+		var all []int32
+		lastDistance := float32(-1)
+		cursor := ""
+		for page := 0; ; page++ {
+			rec, next, err := table.VectorQuery("vec", []float32{0, 0}).
+				Limit(3).WithRowID().After(cursor).ExecutePage(ctx)
+			if err != nil {
+				t.Fatalf("page %d: %v", page, err)
+			}
+			if rec == nil {
+				break
+			}
+			ids := rec.Column(rec.Schema().FieldIndices("id")[0]).(*array.Int32)
+			dist := rec.Column(rec.Schema().FieldIndices("_distance")[0]).(*array.Float32)
+			rowIDs := rec.Column(rec.Schema().FieldIndices("_rowid")[0]).(*array.Uint64)
+			for i := 0; i < ids.Len(); i++ {
+				if dist.Value(i) < lastDistance {
+					t.Fatalf("page %d: distance %v after %v", page, dist.Value(i), lastDistance)
+				}
+				if i > 0 && dist.Value(i) == dist.Value(i-1) && rowIDs.Value(i) < rowIDs.Value(i-1) {
+					t.Fatalf("page %d: ties not ordered by row id", page)
+				}
+				lastDistance = dist.Value(i)
+				all = append(all, ids.Value(i))
+			}
+			rec.Release()
+			if next == "" {
+				break
+			}
+			if page > 5 {
+				t.Fatalf("search did not terminate")
+			}
+			cursor = next
+		}
+		exactlyOnce(t, all, 10)
+	})
+
+	t.Run("ScanAfterRowsMove", func(t *testing.T) {
+		schema, err := internal.NewSchema(arrowSchema)
+		if err != nil {
+			t.Fatalf("failed to create schema: %v", err)
+		}
+		co, ok := conn.(contracts.IConnectionCreateTableOptions)
+		if !ok {
+			t.Fatalf("connection does not implement contracts.IConnectionCreateTableOptions")
+		}
+		moved, err := co.CreateTableWithOptions(ctx, "pages_moved", schema,
+			contracts.CreateTableOptions{EnableStableRowIDs: true})
+		if err != nil {
+			t.Fatalf("CreateTableWithOptions: %v", err)
+		}
+		defer moved.Close()
+		for start := int32(0); start < 10; start += 5 {
+			rec := createRows(t, arrowSchema,
+				[]int32{start, start + 1, start + 2, start + 3, start + 4},
+				[][]float32{{0, 0}, {0, 0}, {0, 0}, {0, 0}, {0, 0}})
+			err := moved.Add(ctx, rec, nil)
+			rec.Release()
+			if err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		// The update rewrites ids 0-2 into a new fragment after the
+		// others while keeping their row IDs, and compaction then merges
+		// the fragments, so scan order no longer follows row IDs. Pages
+		// follow storage order regardless.
+		for id := 0; id < 3; id++ {
+			if err := moved.Update(ctx, fmt.Sprintf("id = %d", id), map[string]interface{}{"id": id}); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+		}
+		if _, err := moved.OptimizeWithAction(ctx, contracts.OptimizeAction{Kind: contracts.OptimizeCompact}); err != nil {
+			t.Fatalf("compact: %v", err)
+		}
+		full, err := moved.Query().Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		want, _ := collect(t, full)
+
+		var all []int32
+		cursor := ""
+		for page := 0; ; page++ {
+			rec, next, err := moved.Query().Limit(3).After(cursor).ExecutePage(ctx)
+			if err != nil {
+				t.Fatalf("page %d: %v", page, err)
+			}
+			ids, hasRowID := collect(t, rec)
+			if hasRowID {
+				t.Fatalf("page %d: _rowid returned without WithRowID", page)
+			}
+			all = append(all, ids...)
+			if next == "" {
+				break
+			}
+			if page > 5 {
+				t.Fatalf("scan did not terminate")
+			}
+			cursor = next
+		}
+		if fmt.Sprint(all) != fmt.Sprint(want) {
+			t.Fatalf("pages returned %v, want storage order %v", all, want)
+		}
+		exactlyOnce(t, all, 10)
+	})
+
+	t.Run("VectorTieCap", func(t *testing.T) {
+		schema, err := internal.NewSchema(arrowSchema)
+		if err != nil {
+			t.Fatalf("failed to create schema: %v", err)
+		}
+		ties, err := conn.CreateTable(ctx, "pages_ties", schema)
+		if err != nil {
+			t.Fatalf("CreateTable: %v", err)
+		}
+		defer ties.Close()
+		ids := make([]int32, 1500)
+		vecs := make([][]float32, len(ids))
+		for i := range ids {
+			ids[i] = int32(i)
+			vecs[i] = []float32{1, 1}
+		}
+		rec := createRows(t, arrowSchema, ids, vecs)
+		err = ties.Add(ctx, rec, nil)
+		rec.Release()
+		if err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+
+		// Every row is at the same distance, so the second full page
+		// would carry 1400 tied row IDs.
+		page, next, err := ties.VectorQuery("vec", []float32{0, 0}).Limit(700).ExecutePage(ctx)
+		if err != nil || next == "" {
+			t.Fatalf("first page: next=%q err=%v", next, err)
+		}
+		page.Release()
+		if _, _, err := ties.VectorQuery("vec", []float32{0, 0}).Limit(700).After(next).ExecutePage(ctx); err == nil {
+			t.Fatalf("a page ending inside more than 1024 ties should fail")
+		}
+	})
+
+	t.Run("InvalidCursor", func(t *testing.T) {
+		if _, _, err := table.Query().Limit(2).After("not a cursor").ExecutePage(ctx); !errors.Is(err, contracts.ErrInvalidCursor) {
+			t.Fatalf("garbage cursor: got %v, want ErrInvalidCursor", err)
+		}
+		_, next, err := table.Query().Limit(2).ExecutePage(ctx)
+		if err != nil || next == "" {
+			t.Fatalf("first page: next=%q err=%v", next, err)
+		}
+		if _, _, err := table.Query().Filter("id > 5").Limit(2).After(next).ExecutePage(ctx); !errors.Is(err, contracts.ErrInvalidCursor) {
+			t.Fatalf("cursor for another query: got %v, want ErrInvalidCursor", err)
+		}
+		if _, _, err := table.Query().Limit(2).Offset(2).ExecutePage(ctx); err == nil {
+			t.Errorf("Offset with ExecutePage should be rejected")
+		}
+	})
+}
+
+// createRows builds an (id, vec) record.
+func createRows(t *testing.T, s *arrow.Schema, ids []int32, vectors [][]float32) arrow.Record {
+	t.Helper()
+	b := array.NewRecordBuilder(memory.NewGoAllocator(), s)
+	defer b.Release()
+	b.Field(0).(*array.Int32Builder).AppendValues(ids, nil)
+	vb := b.Field(1).(*array.FixedSizeListBuilder)
+	fb := vb.ValueBuilder().(*array.Float32Builder)
+	for _, v := range vectors {
+		vb.Append(true)
+		fb.AppendValues(v, nil)
+	}
+	return b.NewRecord()
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestQueryTimeout bounds queries with the builders' Timeout.
+func TestQueryTimeout(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_query_timeout_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "vec", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Float32), Nullable: false},
+	}, nil)
+	table := createTableWithRecord(t, conn, "timeouts", arrowSchema, func(b *array.RecordBuilder) {
+		ib := b.Field(0).(*array.Int32Builder)
+		nb := b.Field(1).(*array.StringBuilder)
+		vb := b.Field(2).(*array.FixedSizeListBuilder)
+		fb := vb.ValueBuilder().(*array.Float32Builder)
+		for i := 0; i < 100; i++ {
+			ib.Append(int32(i))
+			nb.Append(fmt.Sprintf("name-%d", i))
+			vb.Append(true)
+			fb.AppendValues([]float32{float32(i % 7), float32(i % 5)}, nil)
+		}
+	})
+	defer table.Close()
+
+	t.Run("WithinTimeout", func(t *testing.T) {
+		rec, err := table.Query().Filter("id < 10").Timeout(time.Minute).Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		defer rec.Release()
+		if rec.NumRows() != 10 {
+			t.Errorf("got %d rows, want 10", rec.NumRows())
+		}
+
+		vrec, err := table.VectorQuery("vec", []float32{1, 1}).Limit(3).Timeout(time.Minute).Execute(ctx)
+		if err != nil {
+			t.Fatalf("vector Execute: %v", err)
+		}
+		defer vrec.Release()
+		if vrec.NumRows() != 3 {
+			t.Errorf("got %d rows, want 3", vrec.NumRows())
+		}
+	})
+
+	t.Run("ZeroMeansNoLimit", func(t *testing.T) {
+		for _, d := range []time.Duration{0, -time.Second} {
+			rec, err := table.Query().Filter("id >= 50").Timeout(d).Execute(ctx)
+			if err != nil {
+				t.Fatalf("Execute with Timeout(%s): %v", d, err)
+			}
+			rec.Release()
+		}
+	})
+
+	// The remaining cases swap the table's data files for FIFOs with no
+	// writer, so any read blocks until release and the timeout is
+	// certain to elapse first. A fresh connection keeps the earlier
+	// reads' caches out of the way.
+	release := blockDataFiles(t, filepath.Join(tempDir, "timeouts.lance", "data"))
+	defer release()
+	blockedConn, err := lancedb.Connect(ctx, tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer blockedConn.Close()
+	blocked, err := blockedConn.OpenTable(ctx, "timeouts")
+	if err != nil {
+		t.Fatalf("OpenTable: %v", err)
+	}
+	defer blocked.Close()
+
+	t.Run("Elapses", func(t *testing.T) {
+		_, err := blocked.Query().Filter("id < 10").Timeout(50 * time.Millisecond).Execute(ctx)
+		var te *contracts.QueryTimeoutError
+		if !errors.As(err, &te) {
+			t.Fatalf("got %v, want *QueryTimeoutError", err)
+		}
+		if te.Timeout != 50*time.Millisecond {
+			t.Errorf("Timeout = %s, want 50ms", te.Timeout)
+		}
+		if ctx.Err() != nil {
+			t.Errorf("caller's context should be untouched")
+		}
+	})
+
+	t.Run("Vector", func(t *testing.T) {
+		_, err := blocked.VectorQuery("vec", []float32{1, 1}).
+			Limit(5).Timeout(50 * time.Millisecond).Execute(ctx)
+		var te *contracts.QueryTimeoutError
+		if !errors.As(err, &te) {
+			t.Fatalf("got %v, want *QueryTimeoutError", err)
+		}
+	})
+}
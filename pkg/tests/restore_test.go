@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestRestore exercises one-call rollback by version and by tag, plus
+// the expected-current-version guard.
+func TestRestore(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_restore_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	// seed creates a table with two rows (returned version), then adds
+	// a third row. Returns the table, its restore capability, the
+	// two-row version and the current head version.
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableRestore, uint64, uint64) {
+		t.Helper()
+		ctx := context.Background()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{1, 2}, []string{"Alice", "Bob"}, []float64{10, 20})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		base, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		rec2 := buildRecord(t, pool, arrowSchema, []int32{3}, []string{"Carol"}, []float64{30})
+		defer rec2.Release()
+		if err := table.Add(ctx, rec2, nil); err != nil {
+			t.Fatalf("second add: %v", err)
+		}
+		head, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		r, ok := table.(contracts.ITableRestore)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableRestore")
+		}
+		return table, r, uint64(base), uint64(head)
+	}
+
+	t.Run("RestoreVersion", func(t *testing.T) {
+		ctx := context.Background()
+		table, r, base, head := seed(t, "restore_version")
+
+		newVersion, err := r.RestoreVersion(ctx, base, nil)
+		if err != nil {
+			t.Fatalf("RestoreVersion: %v", err)
+		}
+		if newVersion <= head {
+			t.Fatalf("new version %d not after head %d", newVersion, head)
+		}
+		if got, err := table.Version(ctx); err != nil || uint64(got) != newVersion {
+			t.Fatalf("Version = %d, %v; want %d", got, err, newVersion)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("Count = %d, %v; want 2", n, err)
+		}
+
+		// The handle tracks latest, so writes still work.
+		rec := buildRecord(t, pool, arrowSchema, []int32{4}, []string{"Dan"}, []float64{40})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("Add after restore: %v", err)
+		}
+	})
+
+	t.Run("RestoreTag", func(t *testing.T) {
+		ctx := context.Background()
+		table, r, base, _ := seed(t, "restore_tag")
+		if err := table.(contracts.ITableTimeTravel).TagCreate(ctx, "good", base); err != nil {
+			t.Fatalf("TagCreate: %v", err)
+		}
+
+		if _, err := r.RestoreTag(ctx, "good", nil); err != nil {
+			t.Fatalf("RestoreTag: %v", err)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 2 {
+			t.Fatalf("Count = %d, %v; want 2", n, err)
+		}
+	})
+
+	t.Run("ExpectedVersionGuard", func(t *testing.T) {
+		ctx := context.Background()
+		table, r, base, head := seed(t, "restore_guard")
+
+		_, err := r.RestoreVersion(ctx, base, &contracts.RestoreOptions{ExpectedVersion: base})
+		var conflict *contracts.VersionConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("stale guard: got %v, want *VersionConflictError", err)
+		}
+		if conflict.Actual != head || conflict.Committed != 0 {
+			t.Fatalf("conflict = %+v, want Actual %d and nothing committed", conflict, head)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 3 {
+			t.Fatalf("refused restore changed data: Count = %d, %v", n, err)
+		}
+
+		if _, err := r.RestoreVersion(ctx, base, &contracts.RestoreOptions{ExpectedVersion: head}); err != nil {
+			t.Fatalf("matching guard: %v", err)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestRowIDMutations deletes and updates rows selected by `_rowid`
+// from a WithRowID query.
+func TestRowIDMutations(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_row_ids_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableRowIDs) {
+		t.Helper()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		// Two fragments so the deletes span more than one.
+		for _, ids := range [][]int32{{1, 2, 3}, {4, 5, 6}} {
+			rec := buildRecord(t, pool, arrowSchema, ids,
+				[]string{"a", "b", "c"}, []float64{0, 0, 0})
+			err := table.Add(ctx, rec, nil)
+			rec.Release()
+			if err != nil {
+				t.Fatalf("seed add: %v", err)
+			}
+		}
+		r, ok := table.(contracts.ITableRowIDs)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableRowIDs")
+		}
+		return table, r
+	}
+
+	// rowIDs returns the `_rowid` of each row matching filter.
+	rowIDs := func(t *testing.T, table contracts.ITable, filter string) []uint64 {
+		t.Helper()
+		rec, err := table.Query().Filter(filter).Columns([]string{"id"}).WithRowID().Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		defer rec.Release()
+		col := rec.Column(rec.Schema().FieldIndices("_rowid")[0]).(*array.Uint64)
+		return append([]uint64(nil), col.Uint64Values()...)
+	}
+	remainingIDs := func(t *testing.T, table contracts.ITable) []int {
+		t.Helper()
+		rows, err := table.SelectWithColumns(ctx, []string{"id"})
+		if err != nil {
+			t.Fatalf("SelectWithColumns: %v", err)
+		}
+		out := make([]int, 0, len(rows))
+		for _, r := range rows {
+			out = append(out, int(r["id"].(float64)))
+		}
+		sort.Ints(out)
+		return out
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		table, r := seed(t, "row_ids_delete")
+		flagged := rowIDs(t, table, "id IN (2, 4, 5)")
+		before, _ := table.Version(ctx)
+
+		// Duplicates are ignored.
+		res, err := r.DeleteRowIDs(ctx, append(flagged, flagged[0]))
+		if err != nil {
+			t.Fatalf("DeleteRowIDs: %v", err)
+		}
+		if res.RowsDeleted != 3 || res.Version != uint64(before)+1 {
+			t.Fatalf("DeleteRowIDs = %+v, want 3 rows at version %d", res, before+1)
+		}
+		got := remainingIDs(t, table)
+		want := []int{1, 3, 6}
+		if len(got) != len(want) || got[0] != 1 || got[1] != 3 || got[2] != 6 {
+			t.Fatalf("remaining ids %v, want %v", got, want)
+		}
+	})
+
+	t.Run("DeleteWholeFragment", func(t *testing.T) {
+		table, r := seed(t, "row_ids_delete_fragment")
+		res, err := r.DeleteRowIDs(ctx, rowIDs(t, table, "id <= 3"))
+		if err != nil {
+			t.Fatalf("DeleteRowIDs: %v", err)
+		}
+		if res.RowsDeleted != 3 {
+			t.Fatalf("RowsDeleted = %d, want 3", res.RowsDeleted)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 3 {
+			t.Fatalf("Count = %d, %v; want 3", n, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		table, r := seed(t, "row_ids_update")
+		res, err := r.UpdateExprRowIDs(ctx, rowIDs(t, table, "id IN (1, 6)"),
+			[]contracts.UpdateAssignment{{Column: "name", Expr: "'flagged'"}})
+		if err != nil {
+			t.Fatalf("UpdateExprRowIDs: %v", err)
+		}
+		if res.RowsUpdated != 2 {
+			t.Fatalf("RowsUpdated = %d, want 2", res.RowsUpdated)
+		}
+		rows, err := table.SelectWithFilter(ctx, "name = 'flagged'")
+		if err != nil {
+			t.Fatalf("SelectWithFilter: %v", err)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("%d rows flagged, want 2", len(rows))
+		}
+	})
+
+	t.Run("UpdateKeepsStableRowIDs", func(t *testing.T) {
+		co, ok := conn.(contracts.IConnectionCreateTableOptions)
+		if !ok {
+			t.Fatalf("connection does not implement contracts.IConnectionCreateTableOptions")
+		}
+		table, err := co.CreateTableWithOptions(ctx, "row_ids_update_stable", schema,
+			contracts.CreateTableOptions{EnableStableRowIDs: true})
+		if err != nil {
+			t.Fatalf("CreateTableWithOptions: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+		rec := buildRecord(t, pool, arrowSchema, []int32{1, 2, 3},
+			[]string{"a", "b", "c"}, []float64{1, 2, 3})
+		err = table.Add(ctx, rec, nil)
+		rec.Release()
+		if err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		r := table.(contracts.ITableRowIDs)
+
+		ids := rowIDs(t, table, "id >= 2")
+		res, err := r.UpdateExprRowIDs(ctx, ids,
+			[]contracts.UpdateAssignment{{Column: "score", Expr: "score * 10 + id"}})
+		if err != nil {
+			t.Fatalf("UpdateExprRowIDs: %v", err)
+		}
+		if res.RowsUpdated != 2 {
+			t.Fatalf("RowsUpdated = %d, want 2", res.RowsUpdated)
+		}
+		if after := rowIDs(t, table, "score > 20"); len(after) != 2 ||
+			!(after[0] == ids[0] && after[1] == ids[1] || after[0] == ids[1] && after[1] == ids[0]) {
+			t.Fatalf("updated rows have row IDs %v, want %v", after, ids)
+		}
+		if n, err := table.Count(ctx); err != nil || n != 3 {
+			t.Fatalf("Count = %d, %v; want 3", n, err)
+		}
+		rows, err := table.SelectWithFilter(ctx, "id = 3")
+		if err != nil || len(rows) != 1 || rows[0]["score"].(float64) != 33 {
+			t.Fatalf("id 3 after update = %v, %v; want score 33", rows, err)
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		_, r := seed(t, "row_ids_rejected")
+		if _, err := r.DeleteRowIDs(ctx, nil); err == nil {
+			t.Errorf("empty DeleteRowIDs should be rejected")
+		}
+		if _, err := r.UpdateExprRowIDs(ctx, []uint64{0}, nil); err == nil {
+			t.Errorf("UpdateExprRowIDs without assignments should be rejected")
+		}
+	})
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestSample draws seeded random samples through ITableSample.
+func TestSample(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_sample_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	table, err := conn.CreateTable(ctx, "sample", schema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	defer table.Close()
+
+	ids := make([]int32, 100)
+	names := make([]string, 100)
+	scores := make([]float64, 100)
+	for i := range ids {
+		ids[i] = int32(i)
+		names[i] = "even"
+		if i%2 == 1 {
+			names[i] = "odd"
+		}
+		scores[i] = float64(i)
+	}
+	rec := buildRecord(t, pool, arrowSchema, ids, names, scores)
+	err = table.Add(ctx, rec, nil)
+	rec.Release()
+	if err != nil {
+		t.Fatalf("seed add: %v", err)
+	}
+
+	s, ok := table.(contracts.ITableSample)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableSample")
+	}
+
+	// sampledIDs returns the id column of a sample, checking that ids
+	// are distinct and in storage order.
+	sampledIDs := func(t *testing.T, rec arrow.Record, err error) []int32 {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("sample: %v", err)
+		}
+		defer rec.Release()
+		got := append([]int32(nil), rec.Column(rec.Schema().FieldIndices("id")[0]).(*array.Int32).Int32Values()...)
+		for i := 1; i < len(got); i++ {
+			if got[i] <= got[i-1] {
+				t.Fatalf("sample ids not distinct and ascending: %v", got)
+			}
+		}
+		return got
+	}
+	equal := func(a, b []int32) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	t.Run("Seeded", func(t *testing.T) {
+		rec, err := s.Sample(ctx, 10, nil, 42)
+		first := sampledIDs(t, rec, err)
+		if len(first) != 10 {
+			t.Fatalf("got %d rows, want 10", len(first))
+		}
+		rec, err = s.Sample(ctx, 10, nil, 42)
+		if again := sampledIDs(t, rec, err); !equal(first, again) {
+			t.Errorf("same seed gave %v, then %v", first, again)
+		}
+		rec, err = s.Sample(ctx, 10, nil, 7)
+		if other := sampledIDs(t, rec, err); equal(first, other) {
+			t.Errorf("seeds 42 and 7 gave the same sample %v", first)
+		}
+	})
+
+	t.Run("Population", func(t *testing.T) {
+		rec, err := s.Sample(ctx, 1000, nil, 1)
+		if got := sampledIDs(t, rec, err); len(got) != 100 {
+			t.Fatalf("oversized sample has %d rows, want all 100", len(got))
+		}
+		rec, err = s.Sample(ctx, 0, []string{"id"}, 1)
+		if err != nil {
+			t.Fatalf("empty sample: %v", err)
+		}
+		if rec.NumRows() != 0 || rec.NumCols() != 1 {
+			t.Errorf("empty sample = %d rows x %d cols, want 0 x 1", rec.NumRows(), rec.NumCols())
+		}
+		rec.Release()
+	})
+
+	t.Run("Columns", func(t *testing.T) {
+		rec, err := s.Sample(ctx, 5, []string{"id", "score"}, 3)
+		if err != nil {
+			t.Fatalf("Sample: %v", err)
+		}
+		defer rec.Release()
+		if rec.NumCols() != 2 || rec.Schema().HasField("name") {
+			t.Fatalf("schema = %v, want id and score", rec.Schema())
+		}
+	})
+
+	t.Run("Where", func(t *testing.T) {
+		rec, err := s.SampleWhere(ctx, "name = 'odd'", 20, nil, 9)
+		got := sampledIDs(t, rec, err)
+		if len(got) != 20 {
+			t.Fatalf("got %d rows, want 20", len(got))
+		}
+		for _, id := range got {
+			if id%2 != 1 {
+				t.Fatalf("sample %v includes rows outside the filter", got)
+			}
+		}
+		rec, err = s.SampleWhere(ctx, "id < 3", 10, nil, 9)
+		if got := sampledIDs(t, rec, err); !equal(got, []int32{0, 1, 2}) {
+			t.Errorf("small population sample = %v, want [0 1 2]", got)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := s.Sample(ctx, -1, nil, 1); err == nil {
+			t.Errorf("negative n should fail")
+		}
+		if _, err := s.SampleWhere(ctx, " ", 5, nil, 1); err == nil {
+			t.Errorf("empty filter should fail")
+		}
+		if _, err := s.Sample(ctx, 5, []string{"missing"}, 1); err == nil {
+			t.Errorf("unknown column should fail")
+		}
+	})
+}
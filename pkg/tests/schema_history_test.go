@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestSchemaHistory exercises SchemaAt and the per-version diffs of
+// SchemaHistory across add, rename and drop.
+func TestSchemaHistory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_schema_history_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	table, err := conn.CreateTable(ctx, "schema_history", schema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	defer table.Close()
+	rec := buildRecord(t, pool, arrowSchema, []int32{1, 2}, []string{"Alice", "Bob"}, []float64{10, 20})
+	defer rec.Release()
+	if err := table.Add(ctx, rec, nil); err != nil {
+		t.Fatalf("seed add: %v", err)
+	}
+
+	se := table.(contracts.ITableSchemaEvolve)
+	added, err := se.AddColumns(ctx, []contracts.NewColumnTransform{{Name: "score_x2", Expression: "score * 2"}})
+	if err != nil {
+		t.Fatalf("AddColumns: %v", err)
+	}
+	label := "label"
+	renamed, err := se.AlterColumns(ctx, []contracts.ColumnAlteration{{Path: "name", Rename: &label}})
+	if err != nil {
+		t.Fatalf("AlterColumns: %v", err)
+	}
+	dropped, err := se.DropColumns(ctx, []string{"score"})
+	if err != nil {
+		t.Fatalf("DropColumns: %v", err)
+	}
+
+	sh, ok := table.(contracts.ITableSchemaHistory)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableSchemaHistory")
+	}
+
+	t.Run("SchemaAt", func(t *testing.T) {
+		before, err := sh.SchemaAt(ctx, added-1)
+		if err != nil {
+			t.Fatalf("SchemaAt: %v", err)
+		}
+		if _, ok := before.FieldsByName("score_x2"); ok {
+			t.Fatalf("score_x2 present before it was added")
+		}
+		after, err := sh.SchemaAt(ctx, added)
+		if err != nil {
+			t.Fatalf("SchemaAt: %v", err)
+		}
+		if _, ok := after.FieldsByName("score_x2"); !ok {
+			t.Fatalf("score_x2 missing at version %d", added)
+		}
+		if _, err := sh.SchemaAt(ctx, dropped+100); err == nil {
+			t.Fatalf("SchemaAt on a missing version should fail")
+		}
+	})
+
+	t.Run("SchemaHistory", func(t *testing.T) {
+		history, err := sh.SchemaHistory(ctx)
+		if err != nil {
+			t.Fatalf("SchemaHistory: %v", err)
+		}
+		if len(history) == 0 || len(history[0].Diff.Added) != 3 {
+			t.Fatalf("first entry should add all 3 columns, got %+v", history)
+		}
+		byVersion := make(map[uint64]contracts.SchemaDiff, len(history))
+		for _, v := range history {
+			byVersion[v.Version] = v.Diff
+		}
+
+		if d := byVersion[added-1]; !d.IsEmpty() {
+			t.Fatalf("data append changed schema: %+v", d)
+		}
+		if d := byVersion[added]; len(d.Added) != 1 || d.Added[0].Name != "score_x2" || len(d.Dropped) != 0 {
+			t.Fatalf("add diff = %+v", d)
+		}
+		if d := byVersion[renamed]; len(d.Renamed) != 1 || d.Renamed[0] != (contracts.FieldRename{From: "name", To: "label"}) ||
+			len(d.Added) != 0 || len(d.Dropped) != 0 {
+			t.Fatalf("rename diff = %+v", d)
+		}
+		if d := byVersion[dropped]; len(d.Dropped) != 1 || d.Dropped[0].Name != "score" || len(d.Added) != 0 {
+			t.Fatalf("drop diff = %+v", d)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestSchemaMetadata exercises table- and field-level metadata set
+// through SchemaBuilder and updated on an existing table.
+func TestSchemaMetadata(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_schema_metadata_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	schema, err := lancedb.NewSchemaBuilder().
+		AddInt32Field("id", false).
+		AddStringField("name", false).
+		AddFloat64Field("score", true).
+		WithMetadata(map[string]string{"source": "crawl-2026-10"}).
+		WithFieldMetadata("score", map[string]string{"unit": "points"}).
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableMetadata) {
+		t.Helper()
+		table, err := conn.CreateTable(context.Background(), name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, schema.ToArrowSchema(), []int32{1, 2, 3}, []string{"Alice", "Bob", "Carol"}, []float64{10, 20, 30})
+		defer rec.Release()
+		if err := table.Add(context.Background(), rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		md, ok := table.(contracts.ITableMetadata)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableMetadata")
+		}
+		return table, md
+	}
+
+	t.Run("FromSchemaBuilder", func(t *testing.T) {
+		ctx := context.Background()
+		_, md := seed(t, "metadata_builder")
+
+		tm, err := md.TableMetadata(ctx)
+		if err != nil {
+			t.Fatalf("TableMetadata: %v", err)
+		}
+		if tm["source"] != "crawl-2026-10" {
+			t.Errorf("table metadata = %v, want source=crawl-2026-10", tm)
+		}
+		fm, err := md.FieldMetadata(ctx, "score")
+		if err != nil {
+			t.Fatalf("FieldMetadata: %v", err)
+		}
+		if !reflect.DeepEqual(fm, map[string]string{"unit": "points"}) {
+			t.Errorf("field metadata = %v, want unit=points", fm)
+		}
+	})
+
+	t.Run("UpdateTable", func(t *testing.T) {
+		ctx := context.Background()
+		table, md := seed(t, "metadata_update_table")
+		before, _ := table.Version(ctx)
+
+		v, err := md.UpdateTableMetadata(ctx, contracts.MetadataUpdate{
+			Set:    map[string]string{"model": "minilm", "model_version": "2"},
+			Remove: []string{"source"},
+		})
+		if err != nil {
+			t.Fatalf("UpdateTableMetadata: %v", err)
+		}
+		if v != uint64(before)+1 {
+			t.Fatalf("new version = %d, want %d", v, before+1)
+		}
+
+		s, err := table.Schema(ctx)
+		if err != nil {
+			t.Fatalf("Schema: %v", err)
+		}
+		if got, ok := s.Metadata().GetValue("model"); !ok || got != "minilm" {
+			t.Errorf("Schema metadata = %v, want model=minilm", s.Metadata())
+		}
+		if s.Metadata().FindKey("source") >= 0 {
+			t.Errorf("removed key source still present: %v", s.Metadata())
+		}
+
+		if _, err := md.UpdateTableMetadata(ctx, contracts.MetadataUpdate{
+			Set:     map[string]string{"only": "this"},
+			Replace: true,
+		}); err != nil {
+			t.Fatalf("replace: %v", err)
+		}
+		tm, err := md.TableMetadata(ctx)
+		if err != nil {
+			t.Fatalf("TableMetadata: %v", err)
+		}
+		if _, ok := tm["model"]; ok || tm["only"] != "this" {
+			t.Errorf("after replace = %v, want only=this", tm)
+		}
+	})
+
+	t.Run("UpdateField", func(t *testing.T) {
+		ctx := context.Background()
+		table, md := seed(t, "metadata_update_field")
+
+		if _, err := md.UpdateFieldMetadata(ctx, "name", contracts.MetadataUpdate{
+			Set: map[string]string{"provenance": "user input"},
+		}); err != nil {
+			t.Fatalf("UpdateFieldMetadata: %v", err)
+		}
+		s, err := table.Schema(ctx)
+		if err != nil {
+			t.Fatalf("Schema: %v", err)
+		}
+		fields, _ := s.FieldsByName("name")
+		if got, ok := fields[0].Metadata.GetValue("provenance"); !ok || got != "user input" {
+			t.Errorf("name metadata = %v, want provenance=user input", fields[0].Metadata)
+		}
+
+		// Other fields keep their metadata.
+		fm, err := md.FieldMetadata(ctx, "score")
+		if err != nil {
+			t.Fatalf("FieldMetadata: %v", err)
+		}
+		if fm["unit"] != "points" {
+			t.Errorf("score metadata = %v, want unit=points", fm)
+		}
+
+		if n, err := table.Count(ctx); err != nil || n != 3 {
+			t.Fatalf("Count = %d, %v; want 3", n, err)
+		}
+	})
+
+	t.Run("PinnedHandle", func(t *testing.T) {
+		ctx := context.Background()
+		table, md := seed(t, "metadata_pinned")
+		tt := table.(contracts.ITableTimeTravel)
+		if err := tt.Checkout(ctx, 1); err != nil {
+			t.Fatalf("Checkout: %v", err)
+		}
+
+		if _, err := md.UpdateTableMetadata(ctx, contracts.MetadataUpdate{
+			Set: map[string]string{"model": "minilm"},
+		}); err == nil {
+			t.Fatalf("update on a pinned handle should be rejected")
+		}
+		if v, _ := table.Version(ctx); v != 1 {
+			t.Fatalf("rejected update moved the pin to version %d", v)
+		}
+		if err := tt.CheckoutLatest(ctx); err != nil {
+			t.Fatalf("CheckoutLatest: %v", err)
+		}
+		if tm, err := md.TableMetadata(ctx); err != nil || tm["model"] != "" {
+			t.Fatalf("rejected update committed: %v, %v", tm, err)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		ctx := context.Background()
+		table, md := seed(t, "metadata_validation")
+		before, _ := table.Version(ctx)
+
+		if _, err := md.UpdateTableMetadata(ctx, contracts.MetadataUpdate{}); err == nil {
+			t.Errorf("empty update should be rejected")
+		}
+		if _, err := md.UpdateFieldMetadata(ctx, "missing", contracts.MetadataUpdate{Set: map[string]string{"a": "b"}}); err == nil {
+			t.Errorf("unknown field should be rejected")
+		}
+		if _, err := md.UpdateTableMetadata(ctx, contracts.MetadataUpdate{Set: map[string]string{"": "b"}}); err == nil {
+			t.Errorf("empty key should be rejected")
+		}
+		if after, _ := table.Version(ctx); after != before {
+			t.Fatalf("rejected calls committed versions: %d -> %d", before, after)
+		}
+
+		if _, err := lancedb.NewSchemaBuilder().
+			AddInt32Field("id", false).
+			WithFieldMetadata("missing", map[string]string{"a": "b"}).
+			Build(); err == nil {
+			t.Errorf("builder metadata on unknown field should fail Build")
+		}
+	})
+}
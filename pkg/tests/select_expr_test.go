@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestSelectExpr returns computed columns from plain and vector queries.
+func TestSelectExpr(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_select_expr_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "vec", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Float32), Nullable: false},
+	}, nil)
+	table := createTableWithRecord(t, conn, "select_expr", arrowSchema, func(b *array.RecordBuilder) {
+		b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+		b.Field(1).(*array.StringBuilder).AppendValues([]string{"Alpha", "BETA", "gamma"}, nil)
+		b.Field(2).(*array.Float64Builder).AppendValues([]float64{1.5, 2, 4}, nil)
+		vb := b.Field(3).(*array.FixedSizeListBuilder)
+		fb := vb.ValueBuilder().(*array.Float32Builder)
+		for _, v := range [][]float32{{0, 0}, {1, 0}, {5, 5}} {
+			vb.Append(true)
+			fb.AppendValues(v, nil)
+		}
+	})
+	defer table.Close()
+
+	// column returns the named result column rendered as strings.
+	column := func(t *testing.T, rec arrow.Record, name string) []string {
+		t.Helper()
+		idx := rec.Schema().FieldIndices(name)
+		if len(idx) != 1 {
+			t.Fatalf("result has no %s column: %v", name, rec.Schema())
+		}
+		col := rec.Column(idx[0])
+		out := make([]string, col.Len())
+		for i := range out {
+			out[i] = col.ValueStr(i)
+		}
+		return out
+	}
+
+	t.Run("Query", func(t *testing.T) {
+		rec, err := table.Query().
+			Columns([]string{"id"}).
+			SelectExpr(
+				contracts.ColumnExpr{Alias: "t", Expr: "lower(name)"},
+				contracts.ColumnExpr{Alias: "doubled", Expr: "score * 2"},
+			).
+			OrderBy("id", false, false).
+			Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		defer rec.Release()
+
+		var names []string
+		for _, f := range rec.Schema().Fields() {
+			names = append(names, f.Name)
+		}
+		if len(names) != 3 || names[0] != "id" || names[1] != "t" || names[2] != "doubled" {
+			t.Fatalf("columns = %v, want [id t doubled]", names)
+		}
+		if got := column(t, rec, "t"); got[0] != "alpha" || got[1] != "beta" || got[2] != "gamma" {
+			t.Errorf("t = %v", got)
+		}
+		if got := column(t, rec, "doubled"); got[0] != "3" || got[1] != "4" || got[2] != "8" {
+			t.Errorf("doubled = %v", got)
+		}
+	})
+
+	t.Run("Vector", func(t *testing.T) {
+		rec, err := table.VectorQuery("vec", []float32{0, 0}).
+			Limit(2).
+			SelectExpr(contracts.ColumnExprsFromMap(map[string]string{"name_len": "character_length(name)"})...).
+			Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		defer rec.Release()
+		if rec.Schema().HasField("name") || !rec.Schema().HasField("_distance") {
+			t.Fatalf("schema = %v, want name_len and _distance only", rec.Schema())
+		}
+		if got := column(t, rec, "name_len"); len(got) != 2 || got[0] != "5" || got[1] != "4" {
+			t.Errorf("name_len = %v, want [5 4]", got)
+		}
+	})
+
+	t.Run("KeywordAndMixedCaseColumns", func(t *testing.T) {
+		quotedSchema := arrow.NewSchema([]arrow.Field{
+			{Name: "order", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+			{Name: "Title", Type: arrow.BinaryTypes.String, Nullable: false},
+		}, nil)
+		quoted := createTableWithRecord(t, conn, "select_expr_quoted", quotedSchema, func(b *array.RecordBuilder) {
+			b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+			b.Field(1).(*array.StringBuilder).AppendValues([]string{"First", "Second"}, nil)
+		})
+		defer quoted.Close()
+
+		rec, err := quoted.Query().
+			Columns([]string{"order", "Title"}).
+			SelectExpr(contracts.ColumnExpr{Alias: "next", Expr: "`order` + 1"}).
+			Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		defer rec.Release()
+		if got := column(t, rec, "order"); len(got) != 2 || got[0] != "1" || got[1] != "2" {
+			t.Errorf("order = %v, want [1 2]", got)
+		}
+		if got := column(t, rec, "Title"); len(got) != 2 || got[0] != "First" || got[1] != "Second" {
+			t.Errorf("Title = %v, want [First Second]", got)
+		}
+		if got := column(t, rec, "next"); len(got) != 2 || got[0] != "2" || got[1] != "3" {
+			t.Errorf("next = %v, want [2 3]", got)
+		}
+	})
+
+	t.Run("FromMap", func(t *testing.T) {
+		got := contracts.ColumnExprsFromMap(map[string]string{"b": "id", "a": "score", "c": "name"})
+		if len(got) != 3 || got[0].Alias != "a" || got[1].Alias != "b" || got[2].Alias != "c" {
+			t.Fatalf("ColumnExprsFromMap = %v, want ordered by alias", got)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := table.Query().SelectExpr(contracts.ColumnExpr{Alias: "", Expr: "id"}).Execute(ctx); err == nil {
+			t.Errorf("empty alias should fail")
+		}
+		if _, err := table.Query().SelectExpr(contracts.ColumnExpr{Alias: "x", Expr: "missing + 1"}).Execute(ctx); err == nil {
+			t.Errorf("unknown column in expression should fail")
+		}
+	})
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestSQL runs aggregates, a join and a vector UDF through
+// IConnectionSQL.
+func TestSQL(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_sql_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	// items: id, name, score plus a 2-d vector.
+	itemsSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "vec", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Float32), Nullable: false},
+	}, nil)
+	items := createTableWithRecord(t, conn, "items", itemsSchema, func(b *array.RecordBuilder) {
+		b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3, 4}, nil)
+		b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b", "a", "c"}, nil)
+		b.Field(2).(*array.Float64Builder).AppendValues([]float64{1, 2, 3, 4}, nil)
+		vb := b.Field(3).(*array.FixedSizeListBuilder)
+		fb := vb.ValueBuilder().(*array.Float32Builder)
+		for _, v := range [][]float32{{0, 0}, {1, 0}, {0, 3}, {2, 2}} {
+			vb.Append(true)
+			fb.AppendValues(v, nil)
+		}
+	})
+	defer items.Close()
+
+	// lookup: name -> label.
+	lookupSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "label", Type: arrow.BinaryTypes.String, Nullable: false},
+	}, nil)
+	lookup := createTableWithRecord(t, conn, "lookup", lookupSchema, func(b *array.RecordBuilder) {
+		b.Field(0).(*array.StringBuilder).AppendValues([]string{"a", "b", "c"}, nil)
+		b.Field(1).(*array.StringBuilder).AppendValues([]string{"alpha", "beta", "gamma"}, nil)
+	})
+	defer lookup.Close()
+
+	sq, ok := conn.(contracts.IConnectionSQL)
+	if !ok {
+		t.Fatalf("connection does not implement contracts.IConnectionSQL")
+	}
+
+	// run collects every row of the result as strings, keyed by column.
+	run := func(t *testing.T, query string) []map[string]string {
+		t.Helper()
+		rr, err := sq.SQL(ctx, query)
+		if err != nil {
+			t.Fatalf("SQL(%q): %v", query, err)
+		}
+		defer rr.Release()
+		var rows []map[string]string
+		for rr.Next() {
+			rec := rr.Record()
+			for i := 0; i < int(rec.NumRows()); i++ {
+				row := map[string]string{}
+				for c, f := range rec.Schema().Fields() {
+					row[f.Name] = rec.Column(c).ValueStr(i)
+				}
+				rows = append(rows, row)
+			}
+		}
+		if err := rr.Err(); err != nil {
+			t.Fatalf("SQL(%q) stream: %v", query, err)
+		}
+		return rows
+	}
+
+	t.Run("GroupBy", func(t *testing.T) {
+		rows := run(t, "SELECT name, count(*) AS n, sum(score) AS total FROM items GROUP BY name ORDER BY name")
+		want := []string{"a:2:4", "b:1:2", "c:1:4"}
+		if len(rows) != len(want) {
+			t.Fatalf("got %d groups, want %d: %v", len(rows), len(want), rows)
+		}
+		for i, r := range rows {
+			if got := r["name"] + ":" + r["n"] + ":" + r["total"]; got != want[i] {
+				t.Errorf("group %d = %s, want %s", i, got, want[i])
+			}
+		}
+	})
+
+	t.Run("Join", func(t *testing.T) {
+		rows := run(t, "SELECT i.id, l.label FROM items i JOIN lookup l ON i.name = l.name WHERE i.score > 1 ORDER BY i.id")
+		want := []string{"2:beta", "3:alpha", "4:gamma"}
+		if len(rows) != len(want) {
+			t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+		}
+		for i, r := range rows {
+			if got := r["id"] + ":" + r["label"]; got != want[i] {
+				t.Errorf("row %d = %s, want %s", i, got, want[i])
+			}
+		}
+	})
+
+	t.Run("VectorUDF", func(t *testing.T) {
+		rows := run(t, "SELECT id, l2_distance(vec, [1.0, 0.0]) AS d FROM items ORDER BY d, id LIMIT 2")
+		if len(rows) != 2 || rows[0]["id"] != "2" || rows[0]["d"] != "0" || rows[1]["id"] != "1" {
+			t.Fatalf("nearest rows = %v, want id 2 (d=0) then id 1", rows)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := sq.SQL(ctx, "SELECT * FROM missing"); err == nil || !strings.Contains(err.Error(), "missing") {
+			t.Errorf("unknown table error = %v", err)
+		}
+		if _, err := sq.SQL(ctx, " "); err == nil {
+			t.Errorf("empty query should be rejected")
+		}
+	})
+
+	t.Run("EarlyRelease", func(t *testing.T) {
+		rr, err := sq.SQL(ctx, "SELECT * FROM items")
+		if err != nil {
+			t.Fatalf("SQL: %v", err)
+		}
+		if !rr.Schema().HasField("vec") {
+			t.Fatalf("schema = %v", rr.Schema())
+		}
+		rr.Release()
+		if rr.Next() {
+			t.Fatalf("Next after Release should return false")
+		}
+	})
+}
+
+// createTableWithRecord creates a table from schema and adds the one
+// record fill builds.
+func createTableWithRecord(t *testing.T, conn contracts.IConnection, name string, s *arrow.Schema,
+	fill func(*array.RecordBuilder)) contracts.ITable {
+	t.Helper()
+	schema, err := internal.NewSchema(s)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	table, err := conn.CreateTable(context.Background(), name, schema)
+	if err != nil {
+		t.Fatalf("create table %s: %v", name, err)
+	}
+	b := array.NewRecordBuilder(memory.NewGoAllocator(), s)
+	defer b.Release()
+	fill(b)
+	rec := b.NewRecord()
+	defer rec.Release()
+	if err := table.Add(context.Background(), rec, nil); err != nil {
+		t.Fatalf("add to %s: %v", name, err)
+	}
+	return table
+}
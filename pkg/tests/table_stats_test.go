@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestTableStats checks fragment, deletion, file and index figures
+// against a table with a known layout, and that compaction shows up in
+// them.
+func TestTableStats(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_table_stats_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	table, err := conn.CreateTable(ctx, "table_stats", schema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	defer table.Close()
+
+	ts, ok := table.(contracts.ITableStats)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableStats")
+	}
+
+	empty, err := ts.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats on empty table: %v", err)
+	}
+	if empty.NumFragments != 0 || empty.NumRows != 0 || len(empty.Indices) != 0 {
+		t.Fatalf("empty table stats = %+v", empty)
+	}
+
+	// Three fragments of 1, 2 and 3 rows.
+	batches := [][]int32{{1}, {2, 3}, {4, 5, 6}}
+	for _, ids := range batches {
+		names := make([]string, len(ids))
+		scores := make([]float64, len(ids))
+		for i, id := range ids {
+			names[i] = "row"
+			scores[i] = float64(id)
+		}
+		rec := buildRecord(t, pool, arrowSchema, ids, names, scores)
+		err := table.Add(ctx, rec, nil)
+		rec.Release()
+		if err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+	}
+	if err := table.Delete(ctx, "id = 6"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := table.CreateIndex(ctx, []string{"id"}, contracts.IndexTypeBTree); err != nil {
+		t.Fatalf("CreateIndex: %v", err)
+	}
+
+	st, err := ts.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if st.NumFragments != 3 {
+		t.Errorf("NumFragments = %d, want 3", st.NumFragments)
+	}
+	if st.NumRows != 5 || st.NumDeletedRows != 1 {
+		t.Errorf("rows = %d live / %d deleted, want 5 / 1", st.NumRows, st.NumDeletedRows)
+	}
+	if st.NumFragmentsWithDeletions != 1 || st.NumDeletionFiles != 1 {
+		t.Errorf("fragments with deletions = %d, deletion files = %d, want 1, 1",
+			st.NumFragmentsWithDeletions, st.NumDeletionFiles)
+	}
+	if want := (contracts.FragmentRowStats{Min: 1, Max: 2, P50: 2}); st.RowsPerFragment != want {
+		t.Errorf("RowsPerFragment = %+v, want %+v", st.RowsPerFragment, want)
+	}
+	if st.NumDataFiles < 3 {
+		t.Errorf("NumDataFiles = %d, want >= 3", st.NumDataFiles)
+	}
+	if st.TotalBytes == 0 {
+		t.Errorf("TotalBytes = 0")
+	}
+	if len(st.Indices) != 1 || st.Indices[0].Name != "id_idx" || st.Indices[0].SizeBytes == 0 {
+		t.Errorf("Indices = %+v, want one non-empty id_idx", st.Indices)
+	}
+
+	if _, err := table.OptimizeWithAction(ctx, contracts.OptimizeAction{Kind: contracts.OptimizeCompact}); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	compacted, err := ts.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats after compaction: %v", err)
+	}
+	if compacted.NumFragments != 1 || compacted.NumRows != 5 || compacted.NumDeletedRows != 0 {
+		t.Errorf("after compaction = %d fragments, %d rows, %d deleted; want 1, 5, 0",
+			compacted.NumFragments, compacted.NumRows, compacted.NumDeletedRows)
+	}
+}
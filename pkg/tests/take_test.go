@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestTake fetches rows by row ID and by offset and checks they come
+// back in request order.
+func TestTake(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_take_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	table, err := conn.CreateTable(ctx, "take", schema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	defer table.Close()
+
+	rec := buildRecord(t, pool, arrowSchema,
+		[]int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		[]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"},
+		[]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	err = table.Add(ctx, rec, nil)
+	rec.Release()
+	if err != nil {
+		t.Fatalf("seed add: %v", err)
+	}
+
+	tt, ok := table.(contracts.ITableTake)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableTake")
+	}
+
+	idsOf := func(t *testing.T, rec arrow.Record) []int32 {
+		t.Helper()
+		idx := rec.Schema().FieldIndices("id")
+		if len(idx) == 0 {
+			t.Fatalf("result has no id column: %v", rec.Schema())
+		}
+		col := rec.Column(idx[0]).(*array.Int32)
+		out := make([]int32, col.Len())
+		for i := range out {
+			out[i] = col.Value(i)
+		}
+		return out
+	}
+	assertIDs := func(t *testing.T, got, want []int32) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("got ids %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("got ids %v, want %v", got, want)
+			}
+		}
+	}
+
+	t.Run("RowIDs", func(t *testing.T) {
+		hits, err := table.Query().Filter("id IN (2, 5, 8)").Columns([]string{"id"}).WithRowID().Execute(ctx)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		defer hits.Release()
+		rowIDCol := hits.Column(hits.Schema().FieldIndices("_rowid")[0]).(*array.Uint64)
+		byID := map[int32]uint64{}
+		for i, id := range idsOf(t, hits) {
+			byID[id] = rowIDCol.Value(i)
+		}
+
+		// Request order is not storage order.
+		rows, err := tt.TakeRowIDs(ctx, []uint64{byID[8], byID[2], byID[5]}, []string{"id", "name"})
+		if err != nil {
+			t.Fatalf("TakeRowIDs: %v", err)
+		}
+		defer rows.Release()
+		if rows.NumCols() != 2 || rows.Schema().HasField("score") {
+			t.Fatalf("projection not applied: %v", rows.Schema())
+		}
+		assertIDs(t, idsOf(t, rows), []int32{8, 2, 5})
+	})
+
+	t.Run("Offsets", func(t *testing.T) {
+		rows, err := tt.TakeOffsets(ctx, []uint64{9, 0, 4, 4})
+		if err != nil {
+			t.Fatalf("TakeOffsets: %v", err)
+		}
+		defer rows.Release()
+		if rows.NumCols() != 3 {
+			t.Fatalf("TakeOffsets returned %d columns, want all 3", rows.NumCols())
+		}
+		assertIDs(t, idsOf(t, rows), []int32{9, 0, 4, 4})
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		rows, err := tt.TakeRowIDs(ctx, nil, []string{"id"})
+		if err != nil {
+			t.Fatalf("TakeRowIDs: %v", err)
+		}
+		defer rows.Release()
+		if rows.NumRows() != 0 || rows.NumCols() != 1 {
+			t.Fatalf("empty take returned %d rows, %d columns", rows.NumRows(), rows.NumCols())
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		if _, err := tt.TakeOffsets(ctx, []uint64{100}); err == nil {
+			t.Errorf("out-of-range offset should fail")
+		}
+		if _, err := tt.TakeRowIDs(ctx, []uint64{0}, []string{"missing"}); err == nil {
+			t.Errorf("unknown column should fail")
+		}
+	})
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// Typed vector query tests. Each table carries one float64 vector column
+// and one packed-bit uint8 column so both the wide-float and the Hamming
+// paths go through the Arrow-encoded query vector FFI.
+
+const typedVectorDim = 8
+
+// setupTypedVectorTable creates a 4-row table with "f64" as
+// FixedSizeList<Float64, 8> and "bits" as FixedSizeList<Uint8, 1>. Row i
+// has f64 = [i, i, ...] and bits = 1<<i, so nearest-neighbour order is
+// predictable for both columns.
+func setupTypedVectorTable(t *testing.T) (contracts.ITableTypedVectorQuery, func()) {
+	t.Helper()
+
+	tempDir, err := os.MkdirTemp("", "lancedb_test_typed_vector_")
+	require.NoError(t, err)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	f64Type := arrow.FixedSizeListOf(typedVectorDim, arrow.PrimitiveTypes.Float64)
+	bitsType := arrow.FixedSizeListOf(1, arrow.PrimitiveTypes.Uint8)
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "f64", Type: f64Type, Nullable: false},
+		{Name: "bits", Type: bitsType, Nullable: false},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	require.NoError(t, err)
+
+	table, err := conn.CreateTable(context.Background(), "typed_vectors", schema)
+	if err != nil {
+		conn.Close()
+		os.RemoveAll(tempDir)
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	const numRows = 4
+	pool := memory.NewGoAllocator()
+
+	idBuilder := array.NewInt32Builder(pool)
+	f64Builder := array.NewFixedSizeListBuilder(pool, typedVectorDim, arrow.PrimitiveTypes.Float64)
+	f64Values := f64Builder.ValueBuilder().(*array.Float64Builder)
+	bitsBuilder := array.NewFixedSizeListBuilder(pool, 1, arrow.PrimitiveTypes.Uint8)
+	bitsValues := bitsBuilder.ValueBuilder().(*array.Uint8Builder)
+	for i := 0; i < numRows; i++ {
+		idBuilder.Append(int32(i))
+		f64Builder.Append(true)
+		for j := 0; j < typedVectorDim; j++ {
+			f64Values.Append(float64(i))
+		}
+		bitsBuilder.Append(true)
+		bitsValues.Append(uint8(1) << i)
+	}
+	idArray := idBuilder.NewArray()
+	defer idArray.Release()
+	f64Array := f64Builder.NewArray()
+	defer f64Array.Release()
+	bitsArray := bitsBuilder.NewArray()
+	defer bitsArray.Release()
+
+	record := array.NewRecord(arrowSchema, []arrow.Array{idArray, f64Array, bitsArray}, numRows)
+	defer record.Release()
+	if err := table.Add(context.Background(), record, nil); err != nil {
+		table.Close()
+		conn.Close()
+		os.RemoveAll(tempDir)
+		t.Fatalf("Failed to add data: %v", err)
+	}
+
+	tv, ok := table.(contracts.ITableTypedVectorQuery)
+	require.True(t, ok, "table must implement ITableTypedVectorQuery")
+
+	cleanup := func() {
+		table.Close()
+		conn.Close()
+		os.RemoveAll(tempDir)
+	}
+	return tv, cleanup
+}
+
+func firstID(t *testing.T, rec arrow.Record) int32 {
+	t.Helper()
+	require.Greater(t, rec.NumRows(), int64(0))
+	for i, f := range rec.Schema().Fields() {
+		if f.Name == "id" {
+			return rec.Column(i).(*array.Int32).Value(0)
+		}
+	}
+	t.Fatalf("id column missing from result schema")
+	return 0
+}
+
+func TestTypedVectorQuery(t *testing.T) {
+	ctx := context.Background()
+	table, cleanup := setupTypedVectorTable(t)
+	defer cleanup()
+
+	t.Run("Float64", func(t *testing.T) {
+		query := make([]float64, typedVectorDim)
+		for i := range query {
+			query[i] = 2.1
+		}
+		rec, err := table.VectorQueryFloat64("f64", query).Limit(2).Execute(ctx)
+		require.NoError(t, err)
+		defer rec.Release()
+		require.Equal(t, int32(2), firstID(t, rec))
+	})
+
+	t.Run("BinaryHamming", func(t *testing.T) {
+		rec, err := table.VectorQueryBinary("bits", []uint8{1 << 3}).
+			DistanceType(contracts.DistanceTypeHamming).
+			Limit(1).
+			Execute(ctx)
+		require.NoError(t, err)
+		defer rec.Release()
+		require.Equal(t, int32(3), firstID(t, rec))
+	})
+
+	t.Run("EmptyVectorRejected", func(t *testing.T) {
+		rec, err := table.VectorQueryFloat64("f64", nil).Limit(1).Execute(ctx)
+		require.Error(t, err)
+		require.Nil(t, rec)
+	})
+
+	t.Run("HammingRequiresUint8", func(t *testing.T) {
+		rec, err := table.VectorQueryFloat64("f64", make([]float64, typedVectorDim)).
+			DistanceType(contracts.DistanceTypeHamming).
+			Limit(1).
+			Execute(ctx)
+		require.Error(t, err)
+		require.Nil(t, rec)
+		require.Contains(t, err.Error(), "hamming")
+	})
+
+	t.Run("UnsupportedArrowType", func(t *testing.T) {
+		b := array.NewStringBuilder(memory.NewGoAllocator())
+		b.Append("nope")
+		arr := b.NewArray()
+		b.Release()
+		defer arr.Release()
+
+		rec, err := table.VectorQueryArrow("f64", arr).Limit(1).Execute(ctx)
+		require.Error(t, err)
+		require.Nil(t, rec)
+	})
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestWatch exercises version events for commits made through another
+// handle, and channel shutdown on cancellation and Close.
+func TestWatch(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_watch_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+
+	// seed creates a table with two rows and returns it with its watch
+	// capability and current version.
+	seed := func(t *testing.T, name string) (contracts.ITable, contracts.ITableWatch, uint64) {
+		t.Helper()
+		ctx := context.Background()
+		table, err := conn.CreateTable(ctx, name, schema)
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+		t.Cleanup(func() { _ = table.Close() })
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{1, 2}, []string{"Alice", "Bob"}, []float64{10, 20})
+		defer rec.Release()
+		if err := table.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("seed add: %v", err)
+		}
+		base, err := table.Version(ctx)
+		if err != nil {
+			t.Fatalf("Version: %v", err)
+		}
+		w, ok := table.(contracts.ITableWatch)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableWatch")
+		}
+		return table, w, uint64(base)
+	}
+
+	next := func(t *testing.T, events <-chan contracts.VersionEvent) contracts.VersionEvent {
+		t.Helper()
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				t.Fatalf("watch channel closed early")
+			}
+			if ev.Err != nil {
+				t.Fatalf("watch poll failed: %v", ev.Err)
+			}
+			return ev
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for version event")
+		}
+		return contracts.VersionEvent{}
+	}
+
+	t.Run("OtherWriter", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, w, base := seed(t, "watch_other_writer")
+
+		events := w.Watch(ctx, 20*time.Millisecond)
+		// Let the poller record its baseline before writing.
+		time.Sleep(100 * time.Millisecond)
+
+		writer, err := conn.OpenTable(ctx, "watch_other_writer")
+		if err != nil {
+			t.Fatalf("OpenTable: %v", err)
+		}
+		defer writer.Close()
+
+		rec := buildRecord(t, pool, arrowSchema, []int32{3}, []string{"Carol"}, []float64{30})
+		defer rec.Release()
+		if err := writer.Add(ctx, rec, nil); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := writer.Delete(ctx, "id = 1"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+
+		added := next(t, events)
+		if added.Version != base+1 || added.Operation != contracts.VersionOperationAppend {
+			t.Fatalf("first event = v%d %q, want v%d append", added.Version, added.Operation, base+1)
+		}
+		if added.Timestamp.IsZero() {
+			t.Fatalf("event timestamp not set")
+		}
+		deleted := next(t, events)
+		if deleted.Version != base+2 || deleted.Operation != contracts.VersionOperationDelete {
+			t.Fatalf("second event = v%d %q, want v%d delete", deleted.Version, deleted.Operation, base+2)
+		}
+	})
+
+	t.Run("OperationKinds", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		table, w, base := seed(t, "watch_operation_kinds")
+
+		events := w.Watch(ctx, 20*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+
+		if err := table.Update(ctx, "id = 1", map[string]interface{}{"score": 11.0}); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		se, ok := table.(contracts.ITableSchemaEvolve)
+		if !ok {
+			t.Fatalf("table does not implement contracts.ITableSchemaEvolve")
+		}
+		if _, err := se.AddColumns(ctx, []contracts.NewColumnTransform{{Name: "doubled", Expression: "score * 2"}}); err != nil {
+			t.Fatalf("AddColumns: %v", err)
+		}
+
+		if ev := next(t, events); ev.Version != base+1 || ev.Operation != contracts.VersionOperationUpdate {
+			t.Fatalf("first event = v%d %q, want v%d update", ev.Version, ev.Operation, base+1)
+		}
+		if ev := next(t, events); ev.Version != base+2 || ev.Operation != contracts.VersionOperationAddColumns {
+			t.Fatalf("second event = v%d %q, want v%d add_columns", ev.Version, ev.Operation, base+2)
+		}
+	})
+
+	t.Run("PollErrorsAreReported", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, w, base := seed(t, "watch_poll_error")
+
+		events := w.Watch(ctx, 20*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+
+		// A garbage manifest for the next version makes every later poll
+		// fail to read the history.
+		versions := filepath.Join(tempDir, "watch_poll_error.lance", "_versions")
+		name := fmt.Sprintf("%d.manifest", base+1)
+		if _, err := os.Stat(filepath.Join(versions, fmt.Sprintf("%d.manifest", base))); err != nil {
+			// Newer datasets name manifests by inverted, zero-padded version.
+			name = fmt.Sprintf("%020d.manifest", uint64(math.MaxUint64)-(base+1))
+		}
+		if err := os.WriteFile(filepath.Join(versions, name), []byte("not a manifest"), 0o600); err != nil {
+			t.Fatalf("write manifest: %v", err)
+		}
+
+		deadline := time.After(10 * time.Second)
+		for {
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					t.Fatalf("watch channel closed on a poll error")
+				}
+				if ev.Err != nil {
+					if ev.Version != 0 {
+						t.Fatalf("error event carries version %d", ev.Version)
+					}
+					return
+				}
+			case <-deadline:
+				t.Fatalf("no error event after corrupting the history")
+			}
+		}
+	})
+
+	t.Run("CancelClosesChannel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, w, _ := seed(t, "watch_cancel")
+
+		events := w.Watch(ctx, 20*time.Millisecond)
+		cancel()
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Fatalf("unexpected event after cancel")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("channel not closed after cancel")
+		}
+	})
+
+	t.Run("CloseClosesChannel", func(t *testing.T) {
+		table, w, _ := seed(t, "watch_close")
+
+		events := w.Watch(context.Background(), 20*time.Millisecond)
+		if err := table.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Fatalf("unexpected event after Close")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("channel not closed after Close")
+		}
+	})
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/lancedb/lancedb-go/pkg/contracts"
+	"github.com/lancedb/lancedb-go/pkg/internal"
+	"github.com/lancedb/lancedb-go/pkg/lancedb"
+)
+
+// TestWriteFragments exercises the distributed write path: parallel
+// uncommitted writes, a JSON hop to the coordinator, and one commit.
+func TestWriteFragments(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lancedb_test_write_fragments_")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	conn, err := lancedb.Connect(context.Background(), tempDir, nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: false},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: false},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	schema, err := internal.NewSchema(arrowSchema)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	pool := memory.NewGoAllocator()
+	ctx := context.Background()
+
+	table, err := conn.CreateTable(ctx, "write_fragments", schema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	defer table.Close()
+	uri := filepath.Join(tempDir, "write_fragments.lance")
+
+	// worker writes ids [from, from+n) as its own shard.
+	worker := func(t *testing.T, from, n int32, opts *contracts.WriteFragmentsOptions) []contracts.FragmentMetadata {
+		t.Helper()
+		ids := make([]int32, n)
+		names := make([]string, n)
+		scores := make([]float64, n)
+		for i := range ids {
+			ids[i] = from + int32(i)
+			names[i] = "w"
+			scores[i] = float64(ids[i])
+		}
+		rec := buildRecord(t, pool, arrowSchema, ids, names, scores)
+		defer rec.Release()
+		reader, err := array.NewRecordReader(arrowSchema, []arrow.Record{rec})
+		if err != nil {
+			t.Fatalf("NewRecordReader: %v", err)
+		}
+		defer reader.Release()
+		frags, err := lancedb.WriteFragmentsWithOptions(ctx, uri, reader, opts)
+		if err != nil {
+			t.Fatalf("WriteFragments: %v", err)
+		}
+		return frags
+	}
+
+	a := worker(t, 0, 10, nil)
+	b := worker(t, 100, 6, &contracts.WriteFragmentsOptions{MaxRowsPerFile: 4})
+	if len(a) != 1 || a[0].NumRows() != 10 || len(a[0].DataFiles()) == 0 {
+		t.Fatalf("worker a wrote %d fragments, want one with 10 rows and data files", len(a))
+	}
+	if len(b) != 2 {
+		t.Fatalf("worker b wrote %d fragments with MaxRowsPerFile=4, want 2", len(b))
+	}
+
+	if n, err := table.Count(ctx); err != nil || n != 0 {
+		t.Fatalf("uncommitted fragments visible: Count = %d, %v", n, err)
+	}
+
+	// Ship to the coordinator as JSON.
+	payload, err := json.Marshal(append(a, b...))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var received []contracts.FragmentMetadata
+	if err := json.Unmarshal(payload, &received); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	cf, ok := table.(contracts.ITableCommitFragments)
+	if !ok {
+		t.Fatalf("table does not implement contracts.ITableCommitFragments")
+	}
+	before, _ := table.Version(ctx)
+	v, err := cf.CommitFragments(ctx, received)
+	if err != nil {
+		t.Fatalf("CommitFragments: %v", err)
+	}
+	if v != uint64(before)+1 {
+		t.Fatalf("new version = %d, want %d", v, before+1)
+	}
+	if n, err := table.Count(ctx); err != nil || n != 16 {
+		t.Fatalf("Count = %d, %v; want 16", n, err)
+	}
+
+	t.Run("ReadVersion", func(t *testing.T) {
+		for _, f := range received {
+			if f.ReadVersion() != uint64(before) {
+				t.Fatalf("ReadVersion = %d, want %d", f.ReadVersion(), before)
+			}
+		}
+	})
+
+	t.Run("OverwrittenSinceWrite", func(t *testing.T) {
+		stale := worker(t, 200, 3, nil)
+		rec := buildRecord(t, pool, arrowSchema, []int32{1}, []string{"x"}, []float64{1})
+		err := table.Add(ctx, rec, &contracts.AddDataOptions{Mode: contracts.WriteModeOverwrite})
+		rec.Release()
+		if err != nil {
+			t.Fatalf("overwrite: %v", err)
+		}
+		if _, err := cf.CommitFragments(ctx, stale); err == nil {
+			t.Fatalf("fragments written before an overwrite should not commit")
+		}
+		if n, err := table.Count(ctx); err != nil || n != 1 {
+			t.Fatalf("Count = %d, %v; want only the overwritten row", n, err)
+		}
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		rec := buildRecord(t, pool, arrowSchema, []int32{1}, []string{"x"}, []float64{1})
+		defer rec.Release()
+		reader, err := array.NewRecordReader(arrowSchema, []arrow.Record{rec})
+		if err != nil {
+			t.Fatalf("NewRecordReader: %v", err)
+		}
+		defer reader.Release()
+		if _, err := lancedb.WriteFragments(ctx, filepath.Join(tempDir, "missing.lance"), reader); err == nil {
+			t.Errorf("writing to a missing table should fail")
+		}
+		if _, err := cf.CommitFragments(ctx, nil); err == nil {
+			t.Errorf("empty commit should be rejected")
+		}
+	})
+}
@@ -0,0 +1,445 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Fragment-at-a-time column backfill driven from Go.
+//!
+//! A Go callback cannot run inside a lance BatchUDF, so the backfill is
+//! split into steps the caller sequences. A fragment updater handle
+//! wraps lance's fragment Updater: Go pulls one batch of input columns,
+//! hands back the computed columns for that batch, and repeats, so only
+//! one batch of either is held at a time. Finishing the handle writes
+//! the new column files and returns the rewritten fragment as JSON, so
+//! the caller can checkpoint it and skip it after a crash. Every
+//! rewritten fragment is finally committed as one Operation::Merge.
+
+use crate::data::ipc_to_record_batches;
+use crate::dataset::{open_native_dataset, open_writable_dataset};
+use crate::ffi::{from_c_str, SimpleResult};
+use crate::query::write_ipc_result;
+use crate::runtime::get_simple_runtime;
+use arrow_schema::SchemaRef;
+use lance::dataset::transaction::{Operation, Transaction};
+use lance::dataset::updater::Updater;
+use lance::dataset::CommitBuilder;
+use lance_table::format::Fragment;
+use std::ffi::CString;
+use std::os::raw::{c_char, c_void};
+use std::sync::Arc;
+
+fn parse_columns(columns_json: *const c_char) -> Result<Vec<String>, String> {
+    let json = from_c_str(columns_json).map_err(|e| format!("Invalid columns_json: {}", e))?;
+    let columns: Vec<String> =
+        serde_json::from_str(&json).map_err(|e| format!("Failed to parse columns_json: {}", e))?;
+    if columns.is_empty() {
+        return Err("read columns must be non-empty".to_string());
+    }
+    Ok(columns)
+}
+
+fn write_json_out(value: &impl serde::Serialize, out: *mut *mut c_char) -> SimpleResult {
+    match serde_json::to_string(value) {
+        Ok(json_str) => match CString::new(json_str) {
+            Ok(c_string) => {
+                unsafe {
+                    *out = c_string.into_raw();
+                }
+                SimpleResult::ok()
+            }
+            Err(_) => SimpleResult::error("Failed to convert JSON to C string".to_string()),
+        },
+        Err(e) => SimpleResult::error(format!("Failed to serialize result: {}", e)),
+    }
+}
+
+/// List the IDs of the fragments in the version the table handle sees,
+/// as a JSON array of integers. Caller owns ids_json and must free it
+/// with simple_lancedb_free_string.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_fragment_ids(
+    table_handle: *mut c_void,
+    ids_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || ids_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_native_dataset(table).await?;
+            Ok::<_, String>(
+                dataset
+                    .get_fragments()
+                    .iter()
+                    .map(|f| f.id() as u64)
+                    .collect::<Vec<u64>>(),
+            )
+        }) {
+            Ok(ids) => write_json_out(&ids, ids_json),
+            Err(e) => SimpleResult::error(format!("Failed to list fragments: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_fragment_ids".to_string(),
+        ))),
+    }
+}
+
+pub(crate) struct FragmentUpdaterHandle {
+    /// The fragment as it was opened, returned unchanged when it has
+    /// no live rows.
+    fragment: Fragment,
+    updater: Updater,
+    schema: SchemaRef,
+    rows: usize,
+    /// Rows of the batch last returned by next that still await their
+    /// computed columns.
+    pending: Option<usize>,
+    done: bool,
+}
+
+/// Open an updater over one fragment of the version the table handle
+/// sees, reading `columns_json` (a JSON array of column names). On
+/// success *updater_handle is set to a handle driven with
+/// simple_lancedb_fragment_updater_next/update/finish and released with
+/// simple_lancedb_fragment_updater_close. Calls on a single handle must
+/// not overlap.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_fragment_updater_open(
+    table_handle: *mut c_void,
+    fragment_id: u64,
+    columns_json: *const c_char,
+    updater_handle: *mut *mut c_void,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || columns_json.is_null() || updater_handle.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let columns = match parse_columns(columns_json) {
+            Ok(c) => c,
+            Err(e) => return SimpleResult::error(e),
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_native_dataset(table).await?;
+            let schema = Arc::new(arrow_schema::Schema::from(
+                &dataset
+                    .schema()
+                    .project(&columns)
+                    .map_err(|e| e.to_string())?,
+            ));
+            let fragment = dataset
+                .get_fragment(fragment_id as usize)
+                .ok_or_else(|| format!("fragment {} not found", fragment_id))?;
+            let updater = fragment
+                .updater(Some(&columns), None, None)
+                .await
+                .map_err(|e| e.to_string())?;
+            Ok::<_, String>(FragmentUpdaterHandle {
+                fragment: fragment.metadata().clone(),
+                updater,
+                schema,
+                rows: 0,
+                pending: None,
+                done: false,
+            })
+        }) {
+            Ok(handle) => {
+                unsafe {
+                    *updater_handle = Box::into_raw(Box::new(handle)) as *mut c_void;
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to open fragment updater: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_fragment_updater_open".to_string(),
+        ))),
+    }
+}
+
+/// Read the next batch of input columns as an Arrow IPC file. Deleted
+/// rows are skipped. Each batch must be answered with
+/// simple_lancedb_fragment_updater_update before the next is read. At
+/// the end of the fragment *batch_ipc_data is set to NULL and
+/// *batch_ipc_len to 0. The buffer is freed with
+/// simple_lancedb_free_ipc_data.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_fragment_updater_next(
+    updater_handle: *mut c_void,
+    batch_ipc_data: *mut *mut u8,
+    batch_ipc_len: *mut usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if updater_handle.is_null() || batch_ipc_data.is_null() || batch_ipc_len.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let handle = unsafe { &mut *(updater_handle as *mut FragmentUpdaterHandle) };
+        if handle.pending.is_some() {
+            return SimpleResult::error(
+                "the previous batch has not been given its computed columns".to_string(),
+            );
+        }
+        if handle.done {
+            unsafe {
+                *batch_ipc_data = std::ptr::null_mut();
+                *batch_ipc_len = 0;
+            }
+            return SimpleResult::ok();
+        }
+
+        let rt = get_simple_runtime();
+        match rt.block_on(async { handle.updater.next().await.map(|b| b.cloned()) }) {
+            Ok(Some(batch)) => {
+                handle.pending = Some(batch.num_rows());
+                write_ipc_result(&handle.schema, &[batch], batch_ipc_data, batch_ipc_len)
+            }
+            Ok(None) => {
+                handle.done = true;
+                unsafe {
+                    *batch_ipc_data = std::ptr::null_mut();
+                    *batch_ipc_len = 0;
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to read fragment: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_fragment_updater_next".to_string(),
+        ))),
+    }
+}
+
+/// Hand back the computed columns for the batch last returned by
+/// simple_lancedb_fragment_updater_next. `new_columns_ipc` must hold
+/// exactly one row per input row, in the same order.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_fragment_updater_update(
+    updater_handle: *mut c_void,
+    new_columns_ipc: *const u8,
+    new_columns_len: usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if updater_handle.is_null() || new_columns_ipc.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let handle = unsafe { &mut *(updater_handle as *mut FragmentUpdaterHandle) };
+        let expected = match handle.pending {
+            Some(rows) => rows,
+            None => {
+                return SimpleResult::error("no batch is awaiting computed columns".to_string())
+            }
+        };
+        let ipc_bytes = unsafe { std::slice::from_raw_parts(new_columns_ipc, new_columns_len) };
+        let new_batches = match ipc_to_record_batches(ipc_bytes) {
+            Ok(b) => b,
+            Err(e) => return SimpleResult::error(e),
+        };
+        let new_data = match new_batches.first() {
+            Some(first) => match arrow::compute::concat_batches(&first.schema(), &new_batches) {
+                Ok(b) => b,
+                Err(e) => return SimpleResult::error(format!("Failed to combine batches: {}", e)),
+            },
+            None => return SimpleResult::error("new columns are empty".to_string()),
+        };
+        if new_data.num_rows() != expected {
+            return SimpleResult::error(format!(
+                "got {} computed rows for a batch of {}",
+                new_data.num_rows(),
+                expected
+            ));
+        }
+
+        let rt = get_simple_runtime();
+        match rt.block_on(handle.updater.update(new_data)) {
+            Ok(()) => {
+                handle.pending = None;
+                handle.rows += expected;
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to write fragment columns: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_fragment_updater_update".to_string(),
+        ))),
+    }
+}
+
+/// Finish the new column files once every batch has been read and
+/// answered. Nothing is committed: the rewritten fragment is written to
+/// *fragment_json (free with simple_lancedb_free_string) for a later
+/// simple_lancedb_table_commit_merge_columns. A fragment with no live
+/// rows writes no files and is returned unchanged; its new columns read
+/// as null. The handle must still be closed.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_fragment_updater_finish(
+    updater_handle: *mut c_void,
+    fragment_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if updater_handle.is_null() || fragment_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let handle = unsafe { &mut *(updater_handle as *mut FragmentUpdaterHandle) };
+        if !handle.done || handle.pending.is_some() {
+            return SimpleResult::error("the fragment has not been read to the end".to_string());
+        }
+        if handle.rows == 0 {
+            return write_json_out(&handle.fragment, fragment_json);
+        }
+
+        let rt = get_simple_runtime();
+        match rt.block_on(handle.updater.finish()) {
+            Ok(fragment) => write_json_out(&fragment, fragment_json),
+            Err(e) => SimpleResult::error(format!("Failed to write fragment columns: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_fragment_updater_finish".to_string(),
+        ))),
+    }
+}
+
+/// Close a fragment updater handle. Files written for an unfinished
+/// fragment are left for cleanup to remove.
+#[no_mangle]
+pub extern "C" fn simple_lancedb_fragment_updater_close(
+    updater_handle: *mut c_void,
+) -> *mut SimpleResult {
+    if updater_handle.is_null() {
+        return Box::into_raw(Box::new(SimpleResult::error(
+            "Invalid null handle".to_string(),
+        )));
+    }
+
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        unsafe {
+            let _updater = Box::from_raw(updater_handle as *mut FragmentUpdaterHandle);
+        }
+        SimpleResult::ok()
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_fragment_updater_close".to_string(),
+        ))),
+    }
+}
+
+/// Commit fragments finished by simple_lancedb_fragment_updater_finish
+/// as one new version that adds the columns of `output_schema_ipc`.
+/// `fragments_json` is a JSON array holding every fragment of
+/// `read_version`, each as returned by finish. The commit fails
+/// if the table has changed in a conflicting way since read_version,
+/// and is refused while the handle has a version checked out. The
+/// table handle is moved to the new latest version, which is written
+/// to *version_out.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_commit_merge_columns(
+    table_handle: *mut c_void,
+    read_version: u64,
+    fragments_json: *const c_char,
+    output_schema_ipc: *const u8,
+    output_schema_len: usize,
+    version_out: *mut u64,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null()
+            || fragments_json.is_null()
+            || output_schema_ipc.is_null()
+            || version_out.is_null()
+        {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let fragments: Vec<Fragment> = match from_c_str(fragments_json)
+            .map_err(|e| e.to_string())
+            .and_then(|s| serde_json::from_str(&s).map_err(|e| e.to_string()))
+        {
+            Ok(f) => f,
+            Err(e) => return SimpleResult::error(format!("Invalid fragments_json: {}", e)),
+        };
+        let schema_bytes =
+            unsafe { std::slice::from_raw_parts(output_schema_ipc, output_schema_len) };
+        let output_schema = match arrow_ipc::reader::FileReader::try_new(
+            std::io::Cursor::new(schema_bytes),
+            None,
+        ) {
+            Ok(reader) => reader.schema(),
+            Err(e) => return SimpleResult::error(format!("Invalid IPC schema: {}", e)),
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_writable_dataset(table)
+                .await?
+                .checkout_version(read_version)
+                .await
+                .map_err(|e| e.to_string())?;
+            if fragments.len() != dataset.get_fragments().len() {
+                return Err(format!(
+                    "expected {} fragments, got {}",
+                    dataset.get_fragments().len(),
+                    fragments.len()
+                ));
+            }
+            // Same merge the fragment Updater performed, so the field
+            // IDs match the ones recorded in the new data files.
+            let schema = dataset
+                .schema()
+                .merge(output_schema.as_ref())
+                .map_err(|e| e.to_string())?;
+            let transaction =
+                Transaction::new(read_version, Operation::Merge { fragments, schema }, None);
+            let committed = CommitBuilder::new(Arc::new(dataset))
+                .execute(transaction)
+                .await
+                .map_err(|e| e.to_string())?;
+            table.checkout_latest().await.map_err(|e| e.to_string())?;
+            Ok(committed.version().version)
+        }) {
+            Ok(version) => {
+                unsafe {
+                    *version_out = version;
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to commit new columns: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_commit_merge_columns".to_string(),
+        ))),
+    }
+}
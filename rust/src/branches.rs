@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Branch surface — create, list, check out and delete dataset branches.
+//!
+//! lancedb::Table does not wrap lance's branch API, so these entry
+//! points go through the backing lance Dataset. A branch is checked out
+//! as a separate table handle whose writes commit to the branch; the
+//! originating handle keeps tracking its own branch.
+
+use crate::dataset::{open_native_dataset, open_table_for_dataset};
+use crate::ffi::{from_c_str, SimpleResult};
+use crate::runtime::get_simple_runtime;
+use lance::dataset::refs::Ref;
+use std::ffi::CString;
+use std::os::raw::{c_char, c_void};
+
+/// Create a branch named `branch` starting at `from_version`, or at the
+/// version `from_tag` points to when `from_tag` is non-null. Exactly one
+/// of a non-zero `from_version` or a non-null `from_tag` must be given.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_branch_create(
+    table_handle: *mut c_void,
+    branch: *const c_char,
+    from_version: u64,
+    from_tag: *const c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || branch.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let branch_str = match from_c_str(branch) {
+            Ok(s) => s,
+            Err(e) => return SimpleResult::error(format!("Invalid branch: {}", e)),
+        };
+        let from: Ref = if from_tag.is_null() {
+            if from_version == 0 {
+                return SimpleResult::error("a source version or tag is required".to_string());
+            }
+            Ref::from(from_version)
+        } else {
+            if from_version != 0 {
+                return SimpleResult::error(
+                    "set either a source version or a tag, not both".to_string(),
+                );
+            }
+            match from_c_str(from_tag) {
+                Ok(s) => Ref::from(s.as_str()),
+                Err(e) => return SimpleResult::error(format!("Invalid tag: {}", e)),
+            }
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let mut dataset = open_native_dataset(table).await?;
+            dataset
+                .create_branch(&branch_str, from, None)
+                .await
+                .map(|_| ())
+                .map_err(|e| e.to_string())
+        }) {
+            Ok(()) => SimpleResult::ok(),
+            Err(e) => SimpleResult::error(format!("Failed to create branch: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_branch_create".to_string(),
+        ))),
+    }
+}
+
+/// List every branch of the table as a JSON object keyed by branch name.
+/// Each value carries parent_version, created_at (unix seconds),
+/// manifest_size and an optional parent_branch (absent for branches cut
+/// from main). Caller owns branches_json and must free it with
+/// simple_lancedb_free_string.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_branch_list(
+    table_handle: *mut c_void,
+    branches_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || branches_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+
+        match rt.block_on(async {
+            let dataset = open_native_dataset(table).await?;
+            dataset.list_branches().await.map_err(|e| e.to_string())
+        }) {
+            Ok(map) => {
+                // Mapped by hand for the same reason as tags: upstream
+                // BranchContents serializes camelCase.
+                let mapped: std::collections::BTreeMap<String, serde_json::Value> = map
+                    .into_iter()
+                    .map(|(k, v)| {
+                        let mut obj = serde_json::Map::new();
+                        obj.insert(
+                            "parent_version".to_string(),
+                            serde_json::Value::from(v.parent_version),
+                        );
+                        obj.insert(
+                            "created_at".to_string(),
+                            serde_json::Value::from(v.create_at),
+                        );
+                        obj.insert(
+                            "manifest_size".to_string(),
+                            serde_json::Value::from(v.manifest_size as u64),
+                        );
+                        if let Some(parent) = v.parent_branch {
+                            obj.insert(
+                                "parent_branch".to_string(),
+                                serde_json::Value::from(parent),
+                            );
+                        }
+                        (k, serde_json::Value::Object(obj))
+                    })
+                    .collect();
+
+                match serde_json::to_string(&mapped) {
+                    Ok(json_str) => match CString::new(json_str) {
+                        Ok(c_string) => {
+                            unsafe {
+                                *branches_json = c_string.into_raw();
+                            }
+                            SimpleResult::ok()
+                        }
+                        Err(_) => {
+                            SimpleResult::error("Failed to convert JSON to C string".to_string())
+                        }
+                    },
+                    Err(e) => SimpleResult::error(format!("Failed to serialize branches: {}", e)),
+                }
+            }
+            Err(e) => SimpleResult::error(format!("Failed to list branches: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_branch_list".to_string(),
+        ))),
+    }
+}
+
+/// Open a new table handle tracking the latest version of `branch`.
+/// Writes through the new handle commit to the branch. The handle is
+/// written to *branch_handle and must be released with
+/// simple_lancedb_table_close.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_checkout_branch(
+    table_handle: *mut c_void,
+    branch: *const c_char,
+    branch_handle: *mut *mut c_void,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || branch.is_null() || branch_handle.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let branch_str = match from_c_str(branch) {
+            Ok(s) => s,
+            Err(e) => return SimpleResult::error(format!("Invalid branch: {}", e)),
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_native_dataset(table).await?;
+            let branch_dataset = dataset
+                .checkout_branch(&branch_str)
+                .await
+                .map_err(|e| e.to_string())?;
+            open_table_for_dataset(&branch_dataset, table.name()).await
+        }) {
+            Ok(branch_table) => {
+                unsafe {
+                    *branch_handle = Box::into_raw(Box::new(branch_table)) as *mut c_void;
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to checkout branch: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_checkout_branch".to_string(),
+        ))),
+    }
+}
+
+/// Delete a branch. Errors when the branch does not exist.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_branch_delete(
+    table_handle: *mut c_void,
+    branch: *const c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || branch.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let branch_str = match from_c_str(branch) {
+            Ok(s) => s,
+            Err(e) => return SimpleResult::error(format!("Invalid branch: {}", e)),
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let mut dataset = open_native_dataset(table).await?;
+            dataset
+                .delete_branch(&branch_str)
+                .await
+                .map_err(|e| e.to_string())
+        }) {
+            Ok(()) => SimpleResult::ok(),
+            Err(e) => SimpleResult::error(format!("Failed to delete branch: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_branch_delete".to_string(),
+        ))),
+    }
+}
@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Change data feed between two table versions
+//!
+//! Built on Lance's stable row IDs and the per-row version-tracking
+//! columns (`_row_created_at_version`, `_row_last_updated_at_version`).
+//! Inserts and updates are scanned from the `to` snapshot with
+//! `_row_last_updated_at_version > from` pushed down as the scan filter,
+//! and told apart by `_row_created_at_version`. Deletes are read from
+//! the two manifests rather than the data: the live rows of fragments
+//! dropped since `from`, plus the rows newly marked in deletion vectors,
+//! mapped to row IDs through the fragments' row-ID sequences. Only the
+//! deleted rows are then read from `from`, with take_rows. The result
+//! is returned as a stream handle (see the stream module), so neither
+//! snapshot is materialized.
+
+use crate::dataset::open_native_dataset;
+use crate::ffi::SimpleResult;
+use crate::runtime::get_simple_runtime;
+use crate::stream::{new_stream_handle, BatchStream};
+use arrow_array::{new_null_array, Array, ArrayRef, RecordBatch, StringArray, UInt64Array};
+use arrow_schema::{DataType, Field, Schema, SchemaRef};
+use futures::StreamExt;
+use lance::dataset::fragment::FileFragment;
+use lance::dataset::rowids::load_row_id_sequence;
+use lance::dataset::ProjectionRequest;
+use lance::Dataset;
+use lance_core::utils::deletion::DeletionVector;
+use lance_table::format::Fragment;
+use std::collections::{HashMap, HashSet};
+use std::os::raw::c_void;
+use std::sync::Arc;
+
+const ROW_ID_COLUMN: &str = "_rowid";
+const ROW_CREATED_AT_VERSION: &str = "_row_created_at_version";
+const ROW_LAST_UPDATED_AT_VERSION: &str = "_row_last_updated_at_version";
+const CHANGE_TYPE_COLUMN: &str = "_change_type";
+const CHANGE_VERSION_COLUMN: &str = "_change_version";
+
+/// Deleted rows fetched per take_rows call.
+const DELETE_BATCH_ROWS: usize = 8192;
+
+/// The deletion vector of `fragment` in `dataset`, if it has one.
+async fn deletion_vector(
+    dataset: &Arc<Dataset>,
+    fragment: &Fragment,
+) -> Result<Option<Arc<DeletionVector>>, String> {
+    FileFragment::new(dataset.clone(), fragment.clone())
+        .get_deletion_vector()
+        .await
+        .map_err(|e| e.to_string())
+}
+
+/// The row IDs at the offsets of `fragment` that `keep` selects, read
+/// from the fragment's row-ID sequence.
+async fn row_ids_at(
+    dataset: &Dataset,
+    fragment: &Fragment,
+    keep: impl Fn(u32) -> bool,
+) -> Result<Vec<u64>, String> {
+    let sequence = load_row_id_sequence(dataset, fragment)
+        .await
+        .map_err(|e| e.to_string())?;
+    Ok(sequence
+        .iter()
+        .enumerate()
+        .filter(|(offset, _)| keep(*offset as u32))
+        .map(|(_, id)| id)
+        .collect())
+}
+
+/// The row IDs live at `from` and gone at `to`, found from the two
+/// manifests. Fragments whose deletion file did not change are skipped
+/// without reading anything; rows an update or compaction moved to a
+/// fragment added since `from` keep their row IDs and are not deletes.
+async fn deleted_row_ids(from: &Arc<Dataset>, to: &Arc<Dataset>) -> Result<Vec<u64>, String> {
+    let from_fragments: HashSet<u64> = from.manifest().fragments.iter().map(|f| f.id).collect();
+    let to_fragments: HashMap<u64, &Fragment> =
+        to.manifest().fragments.iter().map(|f| (f.id, f)).collect();
+
+    let mut deleted = HashSet::new();
+    for fragment in from.manifest().fragments.iter() {
+        let after = to_fragments.get(&fragment.id);
+        if after.is_some_and(|a| a.deletion_file == fragment.deletion_file) {
+            continue;
+        }
+        let before = deletion_vector(from, fragment).await?;
+        let live_before = |offset: u32| before.as_ref().map_or(true, |dv| !dv.contains(offset));
+        let ids = match after {
+            None => row_ids_at(from, fragment, live_before).await?,
+            Some(after) => {
+                let after = deletion_vector(to, after).await?;
+                row_ids_at(from, fragment, |offset| {
+                    live_before(offset) && after.as_ref().is_some_and(|dv| dv.contains(offset))
+                })
+                .await?
+            }
+        };
+        deleted.extend(ids);
+    }
+
+    for fragment in to
+        .manifest()
+        .fragments
+        .iter()
+        .filter(|f| !from_fragments.contains(&f.id))
+    {
+        if deleted.is_empty() {
+            break;
+        }
+        let dv = deletion_vector(to, fragment).await?;
+        let moved = row_ids_at(to, fragment, |offset| {
+            dv.as_ref().map_or(true, |dv| !dv.contains(offset))
+        })
+        .await?;
+        for id in moved {
+            deleted.remove(&id);
+        }
+    }
+
+    let mut deleted: Vec<u64> = deleted.into_iter().collect();
+    deleted.sort_unstable();
+    Ok(deleted)
+}
+
+fn u64_column<'a>(batch: &'a RecordBatch, name: &str) -> Result<&'a UInt64Array, String> {
+    batch
+        .column_by_name(name)
+        .and_then(|c| c.as_any().downcast_ref::<UInt64Array>())
+        .ok_or_else(|| format!("scan result is missing uint64 column {}", name))
+}
+
+/// Output schema: the `to` data columns, then `_rowid`, `_change_type`
+/// and `_change_version`. A data column absent from (or retyped since)
+/// the `from` snapshot is made nullable so deleted rows can carry null.
+fn output_schema(to_schema: &Schema, from_schema: &Schema) -> SchemaRef {
+    let mut fields: Vec<Field> = to_schema
+        .fields()
+        .iter()
+        .map(|f| {
+            let same = from_schema
+                .field_with_name(f.name())
+                .map(|ff| ff.data_type() == f.data_type())
+                .unwrap_or(false);
+            f.as_ref().clone().with_nullable(f.is_nullable() || !same)
+        })
+        .collect();
+    fields.push(Field::new(ROW_ID_COLUMN, DataType::UInt64, false));
+    fields.push(Field::new(CHANGE_TYPE_COLUMN, DataType::Utf8, false));
+    fields.push(Field::new(CHANGE_VERSION_COLUMN, DataType::UInt64, true));
+    Arc::new(Schema::new(fields))
+}
+
+/// Project `batch` onto the data columns of `schema`, filling columns
+/// the batch lacks (or holds with a different type) with nulls.
+fn project_data_columns(schema: &Schema, batch: &RecordBatch, data_fields: usize) -> Vec<ArrayRef> {
+    schema.fields()[..data_fields]
+        .iter()
+        .map(|f| match batch.column_by_name(f.name()) {
+            Some(c) if c.data_type() == f.data_type() => c.clone(),
+            _ => new_null_array(f.data_type(), batch.num_rows()),
+        })
+        .collect()
+}
+
+/// Label a batch of rows touched after `from_version` as inserts or
+/// updates.
+fn upsert_batch(
+    schema: &SchemaRef,
+    data_fields: usize,
+    batch: &RecordBatch,
+    from_version: u64,
+) -> Result<RecordBatch, String> {
+    let created = u64_column(batch, ROW_CREATED_AT_VERSION)?;
+    let updated = u64_column(batch, ROW_LAST_UPDATED_AT_VERSION)?;
+    let change_type: StringArray = created
+        .iter()
+        .map(|v| {
+            Some(if v.unwrap_or(0) > from_version {
+                "insert"
+            } else {
+                "update"
+            })
+        })
+        .collect();
+
+    let mut columns = project_data_columns(schema, batch, data_fields);
+    columns.push(Arc::new(u64_column(batch, ROW_ID_COLUMN)?.clone()));
+    columns.push(Arc::new(change_type));
+    columns.push(Arc::new(updated.clone()));
+    RecordBatch::try_new(schema.clone(), columns).map_err(|e| e.to_string())
+}
+
+/// Read the deleted rows `row_ids` from the `from` snapshot. Lance does
+/// not record which version removed a row, so _change_version is null.
+async fn delete_batch(
+    schema: SchemaRef,
+    data_fields: usize,
+    from: Arc<Dataset>,
+    row_ids: Vec<u64>,
+) -> Result<RecordBatch, String> {
+    let projection = ProjectionRequest::from_schema(from.schema().clone());
+    let deleted = from
+        .take_rows(&row_ids, projection)
+        .await
+        .map_err(|e| e.to_string())?;
+    let n = deleted.num_rows();
+
+    let mut columns = project_data_columns(&schema, &deleted, data_fields);
+    columns.push(Arc::new(UInt64Array::from(row_ids)));
+    columns.push(Arc::new(StringArray::from(vec!["delete"; n])));
+    columns.push(new_null_array(&DataType::UInt64, n));
+    RecordBatch::try_new(schema, columns).map_err(|e| e.to_string())
+}
+
+async fn compute_changes(
+    to_table: &lancedb::Table,
+    from_table: &lancedb::Table,
+    from_version: u64,
+) -> Result<(SchemaRef, BatchStream), String> {
+    let to = Arc::new(open_native_dataset(to_table).await?);
+    let from = Arc::new(open_native_dataset(from_table).await?);
+    if !to.manifest().uses_stable_row_ids() {
+        return Err("the change feed requires a table created with stable row IDs".to_string());
+    }
+
+    let to_schema = Schema::from(to.schema());
+    let schema = output_schema(&to_schema, &Schema::from(from.schema()));
+    let data_fields = to_schema.fields().len();
+
+    // Deletes: row IDs present at `from` but no longer live at `to`.
+    let deleted = deleted_row_ids(&from, &to).await?;
+
+    // Inserts and updates: rows of `to` touched after `from`.
+    let mut columns: Vec<String> = to_schema
+        .fields()
+        .iter()
+        .map(|f| f.name().clone())
+        .collect();
+    columns.push(ROW_CREATED_AT_VERSION.to_string());
+    columns.push(ROW_LAST_UPDATED_AT_VERSION.to_string());
+    let mut scanner = to.scan();
+    scanner
+        .project(&columns)
+        .map_err(|e| e.to_string())?
+        .with_row_id()
+        .filter(&format!(
+            "{} > {}",
+            ROW_LAST_UPDATED_AT_VERSION, from_version
+        ))
+        .map_err(|e| e.to_string())?;
+    let upserts = scanner.try_into_stream().await.map_err(|e| e.to_string())?;
+
+    let upsert_schema = schema.clone();
+    let upserts = upserts.map(move |batch| {
+        let batch = batch.map_err(|e| e.to_string())?;
+        upsert_batch(&upsert_schema, data_fields, &batch, from_version)
+    });
+    let delete_schema = schema.clone();
+    let chunks: Vec<Vec<u64>> = deleted
+        .chunks(DELETE_BATCH_ROWS)
+        .map(|c| c.to_vec())
+        .collect();
+    let deletes = futures::stream::iter(chunks).then(move |row_ids| {
+        delete_batch(delete_schema.clone(), data_fields, from.clone(), row_ids)
+    });
+
+    Ok((schema, Box::pin(upserts.chain(deletes))))
+}
+
+/// Compute the changes between two snapshots of the same table.
+/// `to_handle` and `from_handle` must be independent table handles
+/// already checked out at the `to` and `from` versions; `from_version`
+/// is the numeric version of `from_handle`. Deleted row IDs are found
+/// up front; the changed rows themselves are read as the stream is
+/// consumed, and the stream does not borrow either handle. On success
+/// *stream_handle is set to a stream read with
+/// simple_lancedb_stream_next and released with
+/// simple_lancedb_stream_close.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_changes(
+    to_handle: *mut c_void,
+    from_handle: *mut c_void,
+    from_version: u64,
+    stream_handle: *mut *mut c_void,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if to_handle.is_null() || from_handle.is_null() || stream_handle.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+
+        let to_table = unsafe { &*(to_handle as *const lancedb::Table) };
+        let from_table = unsafe { &*(from_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+
+        match rt.block_on(compute_changes(to_table, from_table, from_version)) {
+            Ok((schema, stream)) => {
+                unsafe {
+                    *stream_handle = new_stream_handle(schema, stream);
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to compute changes: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_changes".to_string(),
+        ))),
+    }
+}
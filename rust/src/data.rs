@@ -130,6 +130,46 @@ pub extern "C" fn simple_lancedb_table_update(
     }
 }
 
+/// Parse `[{"column":"...", "expr":"..."}]` while rejecting unknown
+/// shapes loudly — silent acceptance of a stray map or missing key
+/// would forward an empty SET clause to lancedb and quietly succeed
+/// with rows_updated=0.
+pub(crate) fn parse_assignments(assignments_str: &str) -> Result<Vec<(String, String)>, String> {
+    let parsed: serde_json::Value = serde_json::from_str(assignments_str)
+        .map_err(|e| format!("Failed to parse assignments JSON: {}", e))?;
+    let arr = parsed
+        .as_array()
+        .ok_or_else(|| "assignments JSON must be an array of {column, expr} objects".to_string())?;
+    if arr.is_empty() {
+        return Err("at least one assignment must be specified".to_string());
+    }
+    let mut pairs: Vec<(String, String)> = Vec::with_capacity(arr.len());
+    for (idx, item) in arr.iter().enumerate() {
+        let obj = item.as_object().ok_or_else(|| {
+            format!(
+                "assignment #{} must be an object with `column` and `expr` keys",
+                idx
+            )
+        })?;
+        let column = match obj.get("column").and_then(|v| v.as_str()) {
+            Some(s) if !s.is_empty() => s.to_string(),
+            _ => {
+                return Err(format!(
+                    "assignment #{}: `column` must be a non-empty string",
+                    idx
+                ))
+            }
+        };
+        let expr = obj
+            .get("expr")
+            .and_then(|v| v.as_str())
+            .ok_or_else(|| format!("assignment #{}: `expr` must be a string", idx))?
+            .to_string();
+        pairs.push((column, expr));
+    }
+    Ok(pairs)
+}
+
 /// Update rows using raw SQL expressions per column, with an optional
 /// predicate. Unlike `simple_lancedb_table_update`, the per-column values
 /// are passed through to lancedb's `UpdateBuilder.column(name, expr)`
@@ -183,58 +223,10 @@ pub extern "C" fn simple_lancedb_table_update_expr(
             Err(e) => return SimpleResult::error(format!("Invalid assignments JSON: {}", e)),
         };
 
-        // Parse `[{"column":"...", "expr":"..."}]` while rejecting unknown
-        // shapes loudly — silent acceptance of a stray map or missing key
-        // would forward an empty SET clause to lancedb and quietly succeed
-        // with rows_updated=0.
-        let parsed: serde_json::Value = match serde_json::from_str(&assignments_str) {
-            Ok(v) => v,
-            Err(e) => {
-                return SimpleResult::error(format!("Failed to parse assignments JSON: {}", e))
-            }
-        };
-        let arr = match parsed.as_array() {
-            Some(a) => a,
-            None => {
-                return SimpleResult::error(
-                    "assignments JSON must be an array of {column, expr} objects".to_string(),
-                )
-            }
+        let pairs = match parse_assignments(&assignments_str) {
+            Ok(p) => p,
+            Err(e) => return SimpleResult::error(e),
         };
-        if arr.is_empty() {
-            return SimpleResult::error("at least one assignment must be specified".to_string());
-        }
-        let mut pairs: Vec<(String, String)> = Vec::with_capacity(arr.len());
-        for (idx, item) in arr.iter().enumerate() {
-            let obj = match item.as_object() {
-                Some(o) => o,
-                None => {
-                    return SimpleResult::error(format!(
-                        "assignment #{} must be an object with `column` and `expr` keys",
-                        idx
-                    ))
-                }
-            };
-            let column = match obj.get("column").and_then(|v| v.as_str()) {
-                Some(s) if !s.is_empty() => s.to_string(),
-                _ => {
-                    return SimpleResult::error(format!(
-                        "assignment #{}: `column` must be a non-empty string",
-                        idx
-                    ))
-                }
-            };
-            let expr = match obj.get("expr").and_then(|v| v.as_str()) {
-                Some(s) => s.to_string(),
-                _ => {
-                    return SimpleResult::error(format!(
-                        "assignment #{}: `expr` must be a string",
-                        idx
-                    ))
-                }
-            };
-            pairs.push((column, expr));
-        }
 
         let table = unsafe { &*(table_handle as *const lancedb::Table) };
         let rt = get_simple_runtime();
@@ -649,7 +641,9 @@ pub extern "C" fn simple_lancedb_table_merge_insert_ipc(
 }
 
 /// Helper function to convert IPC bytes to RecordBatches
-fn ipc_to_record_batches(ipc_bytes: &[u8]) -> Result<Vec<arrow_array::RecordBatch>, String> {
+pub(crate) fn ipc_to_record_batches(
+    ipc_bytes: &[u8],
+) -> Result<Vec<arrow_array::RecordBatch>, String> {
     use arrow_ipc::reader::FileReader;
     use std::io::Cursor;
 
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Access to the lance Dataset behind a native lancedb table, for the
+//! dataset-level features lancedb::Table does not wrap.
+//!
+//! Every helper starts from the Dataset the table handle already holds,
+//! so the object store (with the connection's storage options and
+//! credentials) and the session caches are reused and an up-to-date
+//! handle costs no manifest read. The one reopen is
+//! open_table_for_dataset: lancedb builds a Table only from a location,
+//! so a branch handle opens the branch's own location with the parent's
+//! session and storage options, and is checked against the branch
+//! before it is handed out.
+
+use lance::dataset::ReadParams;
+use lance::io::ObjectStoreParams;
+
+/// A clone of the Dataset held by `table`. Only native (local or
+/// object-store) tables have one; remote tables are rejected.
+async fn held_dataset(table: &lancedb::Table) -> Result<lance::Dataset, String> {
+    let wrapper = table
+        .dataset()
+        .ok_or_else(|| "operation is only supported on native tables".to_string())?;
+    let dataset = wrapper.get().await.map_err(|e| e.to_string())?;
+    Ok((*dataset).clone())
+}
+
+/// Open the lance Dataset backing `table`, checked out at the version
+/// the table handle currently sees.
+pub(crate) async fn open_native_dataset(table: &lancedb::Table) -> Result<lance::Dataset, String> {
+    let version = table.version().await.map_err(|e| e.to_string())?;
+    let dataset = held_dataset(table).await?;
+    if dataset.version().version == version {
+        return Ok(dataset);
+    }
+    dataset
+        .checkout_version(version)
+        .await
+        .map_err(|e| e.to_string())
+}
+
+/// Wrap `dataset` (typically a branch checked out from a table's
+/// dataset) in a lancedb::Table handle named `name`, so it can be
+/// driven through the regular table FFI. lancedb opens tables only by
+/// location, so the handle reopens the dataset's URI, which for a
+/// branch is the branch's own directory, with the same session and
+/// storage options. The reopened dataset must be on the same branch as
+/// `dataset`, so writes through the handle can never land elsewhere.
+pub(crate) async fn open_table_for_dataset(
+    dataset: &lance::Dataset,
+    name: &str,
+) -> Result<lancedb::Table, String> {
+    let params = ReadParams {
+        session: Some(dataset.session()),
+        store_options: Some(ObjectStoreParams {
+            storage_options: dataset.storage_options().cloned(),
+            ..Default::default()
+        }),
+        ..Default::default()
+    };
+    let native = lancedb::table::NativeTable::open_with_params(
+        dataset.uri(),
+        name,
+        vec![],
+        None,
+        Some(params),
+        None,
+    )
+    .await
+    .map_err(|e| e.to_string())?;
+    let table = lancedb::Table::new(std::sync::Arc::new(native));
+
+    let opened = held_dataset(&table).await?;
+    if opened.manifest().branch != dataset.manifest().branch {
+        return Err(format!(
+            "{} opened on branch {:?}, not {:?}",
+            dataset.uri(),
+            opened.manifest().branch,
+            dataset.manifest().branch
+        ));
+    }
+    Ok(table)
+}
+
+/// Open the latest version of the lance Dataset backing `table`,
+/// regardless of which version the handle has checked out. Used where
+/// commits from other writers must be visible.
+pub(crate) async fn open_latest_dataset(table: &lancedb::Table) -> Result<lance::Dataset, String> {
+    let mut dataset = held_dataset(table).await?;
+    dataset.checkout_latest().await.map_err(|e| e.to_string())?;
+    Ok(dataset)
+}
+
+/// Open the latest version of the lance Dataset backing `table` for a
+/// write. Fails, as lancedb's own writes do, when the handle has a
+/// version checked out; the pin is left in place.
+pub(crate) async fn open_writable_dataset(
+    table: &lancedb::Table,
+) -> Result<lance::Dataset, String> {
+    table
+        .dataset()
+        .ok_or_else(|| "operation is only supported on native tables".to_string())?
+        .ensure_mutable()
+        .await
+        .map_err(|e| e.to_string())?;
+    open_latest_dataset(table).await
+}
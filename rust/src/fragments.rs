@@ -0,0 +1,303 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Fragment-level writes for distributed ingestion.
+//!
+//! Workers write data files for an existing table as uncommitted
+//! fragments, without a table handle and from any process; the
+//! manifest entries come back as JSON. A coordinator collects them and
+//! commits them as a single Operation::Append, so readers see either
+//! all of the workers' rows or none of them. Each fragment records the
+//! table version its worker wrote against, and the append is committed
+//! on top of the oldest of them, so lance's conflict resolution rejects
+//! it if the table was overwritten or its schema changed in between.
+//!
+//! For the read side, simple_lancedb_table_fragments lists the
+//! fragments with their row counts so callers can split a scan across
+//! workers (see the "fragment_ids" query config key).
+
+use crate::data::ipc_to_record_batches;
+use crate::dataset::{open_native_dataset, open_writable_dataset};
+use crate::ffi::{from_c_str, SimpleResult};
+use crate::runtime::get_simple_runtime;
+use lance::dataset::transaction::{Operation, Transaction};
+use lance::dataset::{CommitBuilder, InsertBuilder, WriteMode, WriteParams};
+use lance::io::ObjectStoreParams;
+use lance_table::format::Fragment;
+use std::collections::HashMap;
+use std::ffi::CString;
+use std::os::raw::{c_char, c_void};
+use std::sync::Arc;
+
+#[derive(serde::Deserialize, Default)]
+struct WriteFragmentsOptions {
+    #[serde(default)]
+    storage_options: HashMap<String, String>,
+    #[serde(default)]
+    max_rows_per_file: Option<usize>,
+}
+
+/// Write `data_ipc` (Arrow IPC file bytes) as new data files of the
+/// table at `uri` without committing them. `options_json` may be null
+/// or {"storage_options": {..}, "max_rows_per_file": n}. The table must
+/// already exist and the data must match its schema. The uncommitted
+/// fragments are written to *fragments_json as a JSON array (free with
+/// simple_lancedb_free_string) for simple_lancedb_table_commit_fragments;
+/// each entry is a lance Fragment plus the "read_version" it was written
+/// against.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_write_fragments(
+    uri: *const c_char,
+    data_ipc: *const u8,
+    data_len: usize,
+    options_json: *const c_char,
+    fragments_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if uri.is_null() || data_ipc.is_null() || fragments_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let uri = match from_c_str(uri) {
+            Ok(s) => s,
+            Err(e) => return SimpleResult::error(format!("Invalid uri: {}", e)),
+        };
+        let options: WriteFragmentsOptions = if options_json.is_null() {
+            WriteFragmentsOptions::default()
+        } else {
+            match from_c_str(options_json)
+                .map_err(|e| e.to_string())
+                .and_then(|s| serde_json::from_str(&s).map_err(|e| e.to_string()))
+            {
+                Ok(o) => o,
+                Err(e) => return SimpleResult::error(format!("Invalid options_json: {}", e)),
+            }
+        };
+        let ipc_bytes = unsafe { std::slice::from_raw_parts(data_ipc, data_len) };
+        let batches = match ipc_to_record_batches(ipc_bytes) {
+            Ok(b) => b,
+            Err(e) => return SimpleResult::error(e),
+        };
+        if batches.is_empty() {
+            return SimpleResult::error("no data to write".to_string());
+        }
+
+        let mut params = WriteParams {
+            mode: WriteMode::Append,
+            store_params: Some(ObjectStoreParams {
+                storage_options: Some(options.storage_options),
+                ..Default::default()
+            }),
+            ..Default::default()
+        };
+        if let Some(n) = options.max_rows_per_file {
+            params.max_rows_per_file = n;
+        }
+
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let transaction = InsertBuilder::new(uri.as_str())
+                .with_params(&params)
+                .execute_uncommitted(batches)
+                .await
+                .map_err(|e| e.to_string())?;
+            let read_version = transaction.read_version;
+            let fragments = match transaction.operation {
+                Operation::Append { fragments } => fragments,
+                _ => {
+                    return Err(format!(
+                        "table at {} does not exist; create it before writing fragments",
+                        uri
+                    ))
+                }
+            };
+            fragments
+                .iter()
+                .map(|fragment| {
+                    let mut entry = serde_json::to_value(fragment).map_err(|e| e.to_string())?;
+                    if let Some(object) = entry.as_object_mut() {
+                        object.insert(READ_VERSION_KEY.to_string(), read_version.into());
+                    }
+                    Ok(entry)
+                })
+                .collect::<Result<Vec<_>, String>>()
+        }) {
+            Ok(fragments) => match serde_json::to_string(&fragments) {
+                Ok(json) => match CString::new(json) {
+                    Ok(c) => {
+                        unsafe {
+                            *fragments_json = c.into_raw();
+                        }
+                        SimpleResult::ok()
+                    }
+                    Err(_) => SimpleResult::error("Failed to convert JSON to C string".to_string()),
+                },
+                Err(e) => SimpleResult::error(format!("Failed to serialize fragments: {}", e)),
+            },
+            Err(e) => SimpleResult::error(format!("Failed to write fragments: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_write_fragments".to_string(),
+        ))),
+    }
+}
+
+/// Commit fragments returned by simple_lancedb_write_fragments, from
+/// any number of writers, as one append to the latest version of the
+/// table. The append's read version is the oldest "read_version" among
+/// the fragments, so the commit fails if a conflicting operation (an
+/// overwrite, a schema change) landed after any worker started. Fragment
+/// IDs are assigned at commit. Refused while the handle has a version
+/// checked out; otherwise the handle is moved to the new latest
+/// version, which is written to *version_out.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_commit_fragments(
+    table_handle: *mut c_void,
+    fragments_json: *const c_char,
+    version_out: *mut u64,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || fragments_json.is_null() || version_out.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let (fragments, read_version) = match from_c_str(fragments_json)
+            .map_err(|e| e.to_string())
+            .and_then(|s| serde_json::from_str(&s).map_err(|e| e.to_string()))
+            .and_then(parse_written_fragments)
+        {
+            Ok(f) => f,
+            Err(e) => return SimpleResult::error(format!("Invalid fragments_json: {}", e)),
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_writable_dataset(table).await?;
+            let transaction = Transaction::new(read_version, Operation::Append { fragments }, None);
+            let committed = CommitBuilder::new(Arc::new(dataset))
+                .execute(transaction)
+                .await
+                .map_err(|e| e.to_string())?;
+            table.checkout_latest().await.map_err(|e| e.to_string())?;
+            Ok::<_, String>(committed.version().version)
+        }) {
+            Ok(version) => {
+                unsafe {
+                    *version_out = version;
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to commit fragments: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_commit_fragments".to_string(),
+        ))),
+    }
+}
+
+/// Key simple_lancedb_write_fragments adds to each fragment for the
+/// table version it was written against.
+const READ_VERSION_KEY: &str = "read_version";
+
+/// Split written fragments into lance Fragments and the oldest version
+/// any of them was written against.
+fn parse_written_fragments(
+    entries: Vec<serde_json::Value>,
+) -> Result<(Vec<Fragment>, u64), String> {
+    if entries.is_empty() {
+        return Err("no fragments to commit".to_string());
+    }
+    let mut fragments = Vec::with_capacity(entries.len());
+    let mut read_version = u64::MAX;
+    for mut entry in entries {
+        let version = entry
+            .as_object_mut()
+            .and_then(|object| object.remove(READ_VERSION_KEY))
+            .and_then(|v| v.as_u64())
+            .ok_or_else(|| {
+                format!(
+                    "fragment has no {}; write it with simple_lancedb_write_fragments",
+                    READ_VERSION_KEY
+                )
+            })?;
+        read_version = read_version.min(version);
+        fragments.push(serde_json::from_value(entry).map_err(|e| e.to_string())?);
+    }
+    Ok((fragments, read_version))
+}
+
+#[derive(serde::Serialize)]
+struct FragmentInfo {
+    id: u64,
+    num_rows: u64,
+    physical_rows: u64,
+    num_deleted_rows: u64,
+}
+
+/// List the fragments of the version the table handle sees, in
+/// manifest order, as a JSON array of {id, num_rows, physical_rows,
+/// num_deleted_rows}. num_rows excludes deleted rows. Caller owns
+/// fragments_json and must free it with simple_lancedb_free_string.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_fragments(
+    table_handle: *mut c_void,
+    fragments_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || fragments_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_native_dataset(table).await?;
+            let mut infos = Vec::new();
+            for fragment in dataset.get_fragments() {
+                let physical = fragment.physical_rows().await.map_err(|e| e.to_string())? as u64;
+                let deleted = fragment
+                    .count_deletions()
+                    .await
+                    .map_err(|e| e.to_string())? as u64;
+                infos.push(FragmentInfo {
+                    id: fragment.id() as u64,
+                    num_rows: physical.saturating_sub(deleted),
+                    physical_rows: physical,
+                    num_deleted_rows: deleted,
+                });
+            }
+            Ok::<_, String>(infos)
+        }) {
+            Ok(infos) => match serde_json::to_string(&infos) {
+                Ok(json) => match CString::new(json) {
+                    Ok(c) => {
+                        unsafe {
+                            *fragments_json = c.into_raw();
+                        }
+                        SimpleResult::ok()
+                    }
+                    Err(_) => SimpleResult::error("Failed to convert JSON to C string".to_string()),
+                },
+                Err(e) => SimpleResult::error(format!("Failed to serialize fragments: {}", e)),
+            },
+            Err(e) => SimpleResult::error(format!("Failed to list fragments: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_fragments".to_string(),
+        ))),
+    }
+}
@@ -3,30 +3,51 @@
 
 //! Simple library entry point for Go bindings
 
+pub mod backfill;
+pub mod branches;
+pub mod changes;
 pub mod connection;
 pub mod conversion;
 pub mod data;
 pub mod database;
+pub mod dataset;
 pub mod ffi;
+pub mod fragments;
 pub mod index;
 pub mod metadata;
 pub mod query;
 pub mod refs;
+pub mod row_ids;
 pub mod runtime;
+pub mod sample;
 pub mod schema;
 pub mod schema_evolve;
+pub mod sql;
+pub mod stats;
+pub mod stream;
 pub mod table;
+pub mod take;
 pub mod types;
 
 // Re-export all public functions and types
+pub use backfill::*;
+pub use branches::*;
+pub use changes::*;
 pub use connection::*;
 pub use data::*;
 pub use database::*;
 pub use ffi::*;
+pub use fragments::*;
 pub use index::*;
 pub use metadata::*;
 pub use query::*;
 pub use refs::*;
+pub use row_ids::*;
+pub use sample::*;
 pub use schema_evolve::*;
+pub use sql::*;
+pub use stats::*;
+pub use stream::*;
 pub use table::*;
+pub use take::*;
 pub use types::*;
@@ -3,8 +3,10 @@
 
 //! Table metadata operations
 
-use crate::ffi::SimpleResult;
+use crate::dataset::{open_latest_dataset, open_writable_dataset};
+use crate::ffi::{from_c_str, SimpleResult};
 use crate::runtime::get_simple_runtime;
+use std::collections::HashMap;
 use std::ffi::CString;
 use std::os::raw::{c_char, c_void};
 
@@ -42,6 +44,52 @@ pub extern "C" fn simple_lancedb_table_count_rows(
     }
 }
 
+/// Count the rows matching `filter`, a SQL predicate evaluated with
+/// lancedb's count_rows so scalar indexes are used where they apply.
+/// A null or empty filter counts every row.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_count_rows_where(
+    table_handle: *mut c_void,
+    filter: *const c_char,
+    count: *mut i64,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || count.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let filter = if filter.is_null() {
+            None
+        } else {
+            match from_c_str(filter) {
+                Ok(s) if s.trim().is_empty() => None,
+                Ok(s) => Some(s),
+                Err(e) => return SimpleResult::error(format!("Invalid filter: {}", e)),
+            }
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+
+        match rt.block_on(async { table.count_rows(filter).await }) {
+            Ok(row_count) => {
+                unsafe {
+                    *count = row_count as i64;
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to count rows: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_count_rows_where".to_string(),
+        ))),
+    }
+}
+
 /// Get table version (simple version)
 #[no_mangle]
 #[allow(clippy::not_unsafe_ptr_arg_deref)]
@@ -212,6 +260,213 @@ pub extern "C" fn simple_lancedb_table_schema_ipc(
     }
 }
 
+/// Get the table schema as of `version` in Arrow IPC format, together
+/// with a JSON object mapping each top-level field name to its lance
+/// field ID. Field IDs survive renames, so callers can tell a renamed
+/// column from a dropped one. The IPC buffer is freed with
+/// simple_lancedb_free_ipc_data and field_ids_json with
+/// simple_lancedb_free_string.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_schema_at_ipc(
+    table_handle: *mut c_void,
+    version: u64,
+    schema_ipc_data: *mut *mut u8,
+    schema_ipc_len: *mut usize,
+    field_ids_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null()
+            || schema_ipc_data.is_null()
+            || schema_ipc_len.is_null()
+            || field_ids_json.is_null()
+        {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+
+        match rt.block_on(async {
+            let dataset = open_latest_dataset(table).await?;
+            let dataset = dataset
+                .checkout_version(version)
+                .await
+                .map_err(|e| e.to_string())?;
+            let lance_schema = dataset.schema();
+            let ids: std::collections::BTreeMap<String, i32> = lance_schema
+                .fields
+                .iter()
+                .map(|f| (f.name.clone(), f.id))
+                .collect();
+            Ok::<_, String>((arrow_schema::Schema::from(lance_schema), ids))
+        }) {
+            Ok((arrow_schema, ids)) => {
+                let ipc_bytes = match schema_to_ipc_bytes(&arrow_schema) {
+                    Ok(b) => b,
+                    Err(e) => {
+                        return SimpleResult::error(format!(
+                            "Failed to serialize schema to IPC: {}",
+                            e
+                        ))
+                    }
+                };
+                let ids_str = match serde_json::to_string(&ids) {
+                    Ok(s) => s,
+                    Err(e) => {
+                        return SimpleResult::error(format!("Failed to serialize field IDs: {}", e))
+                    }
+                };
+                let ids_c = match CString::new(ids_str) {
+                    Ok(c) => c,
+                    Err(_) => {
+                        return SimpleResult::error(
+                            "Failed to convert JSON to C string".to_string(),
+                        )
+                    }
+                };
+
+                let len = ipc_bytes.len();
+                let data_ptr = unsafe { libc::malloc(len) as *mut u8 };
+                if data_ptr.is_null() {
+                    return SimpleResult::error(
+                        "Failed to allocate memory for IPC data".to_string(),
+                    );
+                }
+                unsafe {
+                    std::ptr::copy_nonoverlapping(ipc_bytes.as_ptr(), data_ptr, len);
+                    *schema_ipc_data = data_ptr;
+                    *schema_ipc_len = len;
+                    *field_ids_json = ids_c.into_raw();
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!(
+                "Failed to get table schema at version {}: {}",
+                version, e
+            )),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_schema_at_ipc".to_string(),
+        ))),
+    }
+}
+
+/// A metadata change as sent from Go: keys to set, keys to remove, and
+/// whether to start from an empty map instead of the current one.
+#[derive(serde::Deserialize)]
+struct MetadataUpdate {
+    #[serde(default)]
+    set: HashMap<String, String>,
+    #[serde(default)]
+    remove: Vec<String>,
+    #[serde(default)]
+    replace: bool,
+}
+
+impl MetadataUpdate {
+    fn apply(self, current: &HashMap<String, String>) -> HashMap<String, String> {
+        let mut merged = if self.replace {
+            HashMap::new()
+        } else {
+            current.clone()
+        };
+        merged.extend(self.set);
+        for key in &self.remove {
+            merged.remove(key);
+        }
+        merged
+    }
+}
+
+/// Update the schema-level metadata of the table, or the metadata of
+/// the field at `field_path` (dot-separated for nested fields) when it
+/// is non-null. `update_json` is {"set": {k: v}, "remove": [k],
+/// "replace": bool}; keys in both set and remove are removed. The
+/// change is committed as a new version against the latest dataset,
+/// the table handle is moved to it, and its number is written to
+/// *version_out. Refused while the handle has a version checked out.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_update_metadata(
+    table_handle: *mut c_void,
+    field_path: *const c_char,
+    update_json: *const c_char,
+    version_out: *mut u64,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || update_json.is_null() || version_out.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let update: MetadataUpdate = match from_c_str(update_json)
+            .map_err(|e| e.to_string())
+            .and_then(|s| serde_json::from_str(&s).map_err(|e| e.to_string()))
+        {
+            Ok(u) => u,
+            Err(e) => return SimpleResult::error(format!("Invalid update_json: {}", e)),
+        };
+        let path = if field_path.is_null() {
+            None
+        } else {
+            match from_c_str(field_path) {
+                Ok(p) => Some(p),
+                Err(e) => return SimpleResult::error(format!("Invalid field_path: {}", e)),
+            }
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+
+        match rt.block_on(async {
+            let mut dataset = open_writable_dataset(table).await?;
+            match &path {
+                None => {
+                    let merged = update.apply(&dataset.schema().metadata);
+                    dataset
+                        .update_schema_metadata(merged)
+                        .replace()
+                        .await
+                        .map_err(|e| e.to_string())?;
+                }
+                Some(p) => {
+                    let field = dataset
+                        .schema()
+                        .field(p)
+                        .ok_or_else(|| format!("field {} not found", p))?;
+                    let merged = update.apply(&field.metadata);
+                    dataset
+                        .update_field_metadata()
+                        .replace(p.as_str(), merged)
+                        .map_err(|e| e.to_string())?
+                        .await
+                        .map_err(|e| e.to_string())?;
+                }
+            }
+            table.checkout_latest().await.map_err(|e| e.to_string())?;
+            Ok::<_, String>(dataset.version().version)
+        }) {
+            Ok(version) => {
+                unsafe {
+                    *version_out = version;
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to update metadata: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_update_metadata".to_string(),
+        ))),
+    }
+}
+
 /// Free IPC schema data allocated by simple_lancedb_table_schema_ipc
 #[no_mangle]
 pub extern "C" fn simple_lancedb_free_ipc_data(data: *mut u8) {
@@ -4,9 +4,15 @@
 //! Query and search operations
 
 use crate::conversion::convert_arrow_value_to_json;
+use crate::data::ipc_to_record_batches;
+use crate::dataset::open_native_dataset;
 use crate::ffi::{from_c_str, SimpleResult};
 use crate::runtime::get_simple_runtime;
+use arrow_array::{Array, ArrayRef, Float32Array};
+use arrow_schema::DataType;
+use lance::dataset::scanner::ColumnOrdering;
 use lancedb::index::scalar::FullTextSearchQuery;
+use lancedb::index::IndexType;
 use lancedb::query::{ExecutableQuery, QueryBase};
 use lancedb::rerankers::rrf::RRFReranker;
 use lancedb::rerankers::{NormalizeMethod, Reranker};
@@ -24,6 +30,61 @@ fn parse_column_names(columns: &[serde_json::Value]) -> Vec<String> {
         .collect()
 }
 
+/// Parse the projection of a query config: `columns` selects existing
+/// columns by name and `select_exprs`, a list of {"alias", "expr"}
+/// pairs, adds computed columns after them. Returns None when neither is
+/// set, so the query keeps every column.
+fn parse_select(
+    query_config: &serde_json::Value,
+) -> Result<Option<lancedb::query::Select>, lancedb::Error> {
+    let column_names = query_config
+        .get("columns")
+        .and_then(|v| v.as_array())
+        .map(|columns| parse_column_names(columns))
+        .unwrap_or_default();
+    let exprs = match query_config.get("select_exprs").and_then(|v| v.as_array()) {
+        Some(exprs) => exprs,
+        None if column_names.is_empty() => return Ok(None),
+        None => return Ok(Some(lancedb::query::Select::Columns(column_names))),
+    };
+
+    let mut pairs: Vec<(String, String)> = column_names
+        .into_iter()
+        .map(|name| {
+            let expr = quote_column_path(&name);
+            (name, expr)
+        })
+        .collect();
+    for (idx, pair) in exprs.iter().enumerate() {
+        let field = |key: &str| {
+            pair.get(key)
+                .and_then(|v| v.as_str())
+                .map(str::trim)
+                .filter(|s| !s.is_empty())
+                .map(str::to_string)
+                .ok_or_else(|| lancedb::Error::InvalidInput {
+                    message: format!(
+                        "select_exprs #{}: `{}` must be a non-empty string",
+                        idx, key
+                    ),
+                })
+        };
+        pairs.push((field("alias")?, field("expr")?));
+    }
+    Ok(Some(lancedb::query::Select::Dynamic(pairs)))
+}
+
+/// Render a column path as a SQL expression for Select::Dynamic. Each
+/// dot-separated segment is backtick-quoted, with backticks doubled, so
+/// keywords (`order`) and mixed-case names (`Title`) are taken as
+/// identifiers rather than parsed or case-folded.
+fn quote_column_path(path: &str) -> String {
+    path.split('.')
+        .map(|segment| format!("`{}`", segment.replace('`', "``")))
+        .collect::<Vec<_>>()
+        .join(".")
+}
+
 /// Parse a distance type string into a LanceDB DistanceType. Shared by the
 /// query path and the index path (rust/src/index.rs), which wraps any
 /// error into String via map_err.
@@ -39,6 +100,32 @@ pub(crate) fn parse_distance_type(dt: &str) -> Result<lancedb::DistanceType, lan
     }
 }
 
+/// Decode a query vector shipped by the Go layer as a single-column
+/// Arrow IPC file. The column's element type is preserved as-is
+/// (Float16 / Float32 / Float64 / Int8 / UInt8) so lancedb can match it
+/// against the vector column's native type — packed-bit UInt8 vectors
+/// in particular must not be widened to floats for Hamming search.
+pub(crate) fn decode_query_vector(ipc_bytes: &[u8]) -> Result<ArrayRef, String> {
+    let batches = ipc_to_record_batches(ipc_bytes)?;
+    let batch = batches
+        .first()
+        .ok_or_else(|| "query vector IPC payload has no record batch".to_string())?;
+    if batch.num_columns() != 1 {
+        return Err(format!(
+            "query vector IPC payload must carry exactly one column, got {}",
+            batch.num_columns()
+        ));
+    }
+    let vector = batch.column(0).clone();
+    if vector.is_empty() {
+        return Err("query vector must be non-empty".to_string());
+    }
+    if vector.null_count() > 0 {
+        return Err("query vector must not contain nulls".to_string());
+    }
+    Ok(vector)
+}
+
 /// Default RRF k parameter. Matches lancedb::rerankers::rrf::RRFReranker's
 /// own default so an omitted k produces identical behaviour.
 const DEFAULT_RRF_K: f32 = 60.0;
@@ -139,35 +226,82 @@ pub(crate) fn apply_query_base_flags<Q: QueryBase>(
 
 /// Build and execute a query from JSON config, returning a record batch stream.
 ///
-/// Handles three query modes based on config contents:
+/// Handles four query modes based on config contents:
 /// - Vector search: nearest_to() with optional distance type, filter, columns
 /// - Full-text search: FullTextSearchQuery with optional column, filter, limit
 /// - Standard query: filter, limit, offset, column selection
+/// - Native scan: a standard query restricted to `fragment_ids` and/or
+///   sorted by `order_by`; fast_search, postfilter and reranker are
+///   rejected there rather than ignored
+///
+/// `query_vector`, when present, is the Arrow-encoded query for the vector
+/// search branch and takes precedence over the legacy JSON `vector` float
+/// list in the config.
 async fn execute_query_from_config(
     table: &lancedb::Table,
     query_config: &serde_json::Value,
+    query_vector: Option<ArrayRef>,
 ) -> Result<
     impl tokio_stream::Stream<Item = Result<arrow_array::RecordBatch, lancedb::Error>>,
     lancedb::Error,
 > {
+    let native_scan = query_config
+        .get("fragment_ids")
+        .is_some_and(|v| !v.is_null())
+        || query_config.get("order_by").is_some();
+    if native_scan
+        && (query_config.get("vector_search").is_some() || query_config.get("fts_search").is_some())
+    {
+        return Err(lancedb::Error::InvalidInput {
+            message: "fragment_ids and order_by only apply to plain scans".to_string(),
+        });
+    }
+    if native_scan {
+        let flag = |name: &str| {
+            query_config
+                .get(name)
+                .and_then(|v| v.as_bool())
+                .unwrap_or(false)
+        };
+        let unsupported = [
+            ("fast_search", flag("fast_search")),
+            ("postfilter", flag("postfilter")),
+            (
+                "reranker",
+                query_config.get("reranker").is_some_and(|v| !v.is_null()),
+            ),
+        ];
+        if let Some((name, _)) = unsupported.iter().find(|(_, set)| *set) {
+            return Err(lancedb::Error::InvalidInput {
+                message: format!("{} cannot be combined with fragment_ids or order_by", name),
+            });
+        }
+    }
+
     // Vector search
     if let Some(vector_search) = query_config.get("vector_search") {
-        if let (Some(column), Some(vector_values), Some(k)) = (
+        let vector: Result<Option<ArrayRef>, String> = match query_vector {
+            Some(v) => Ok(Some(v)),
+            None => match vector_search.get("vector").and_then(|v| v.as_array()) {
+                Some(values) => values
+                    .iter()
+                    .map(|v| {
+                        v.as_f64()
+                            .map(|f| f as f32)
+                            .ok_or_else(|| "Invalid vector element".to_string())
+                    })
+                    .collect::<Result<Vec<f32>, String>>()
+                    .map(|vec| Some(Arc::new(Float32Array::from(vec)) as ArrayRef)),
+                None => Ok(None),
+            },
+        };
+
+        if let (Some(column), Some(k)) = (
             vector_search.get("column").and_then(|v| v.as_str()),
-            vector_search.get("vector").and_then(|v| v.as_array()),
             vector_search.get("k").and_then(|v| v.as_u64()),
         ) {
-            let vector: Result<Vec<f32>, String> = vector_values
-                .iter()
-                .map(|v| {
-                    v.as_f64()
-                        .map(|f| f as f32)
-                        .ok_or_else(|| "Invalid vector element".to_string())
-                })
-                .collect();
-
             match vector {
-                Ok(vec) => {
+                Ok(Some(vec)) => {
                     let effective_limit = query_config
                         .get("limit")
                         .and_then(|v| v.as_u64())
@@ -184,12 +318,8 @@ async fn execute_query_from_config(
                         vector_query = vector_query.only_if(filter);
                     }
 
-                    if let Some(columns) = query_config.get("columns").and_then(|v| v.as_array()) {
-                        let column_names = parse_column_names(columns);
-                        if !column_names.is_empty() {
-                            vector_query =
-                                vector_query.select(lancedb::query::Select::Columns(column_names));
-                        }
+                    if let Some(select) = parse_select(query_config)? {
+                        vector_query = vector_query.select(select);
                     }
 
                     if let Some(dt) = vector_search.get("distance_type").and_then(|v| v.as_str()) {
@@ -213,6 +343,13 @@ async fn execute_query_from_config(
                     {
                         vector_query = vector_query.bypass_vector_index();
                     }
+                    // Cursor pagination resumes at the last distance seen.
+                    if let Some(lower) = vector_search
+                        .get("distance_lower_bound")
+                        .and_then(|v| v.as_f64())
+                    {
+                        vector_query = vector_query.distance_range(Some(lower as f32), None);
+                    }
 
                     // Hybrid: when a full_text_query is present alongside the
                     // vector, chain .full_text_search() so lancedb's
@@ -251,6 +388,11 @@ async fn execute_query_from_config(
 
                     return vector_query.execute().await;
                 }
+                Ok(None) => {
+                    return Err(lancedb::Error::InvalidInput {
+                        message: "vector_search requires a query vector".to_string(),
+                    })
+                }
                 Err(e) => {
                     return Err(lancedb::Error::InvalidInput {
                         message: format!("Failed to parse vector: {}", e),
@@ -281,11 +423,8 @@ async fn execute_query_from_config(
 
         let mut fts_query = table.query().full_text_search(fts_query_obj);
 
-        if let Some(columns) = query_config.get("columns").and_then(|v| v.as_array()) {
-            let column_names = parse_column_names(columns);
-            if !column_names.is_empty() {
-                fts_query = fts_query.select(lancedb::query::Select::Columns(column_names));
-            }
+        if let Some(select) = parse_select(query_config)? {
+            fts_query = fts_query.select(select);
         }
         if let Some(filter) = query_config.get("where").and_then(|v| v.as_str()) {
             fts_query = fts_query.only_if(filter);
@@ -309,14 +448,16 @@ async fn execute_query_from_config(
         return fts_query.execute().await;
     }
 
+    // Fragment-restricted or ordered scan
+    if native_scan {
+        return scan_native(table, query_config).await;
+    }
+
     // Standard query
     let mut query = table.query();
 
-    if let Some(columns) = query_config.get("columns").and_then(|v| v.as_array()) {
-        let column_names = parse_column_names(columns);
-        if !column_names.is_empty() {
-            query = query.select(lancedb::query::Select::Columns(column_names));
-        }
+    if let Some(select) = parse_select(query_config)? {
+        query = query.select(select);
     }
 
     if let Some(limit) = query_config.get("limit").and_then(|v| v.as_u64()) {
@@ -336,14 +477,314 @@ async fn execute_query_from_config(
     query.execute().await
 }
 
-/// Parse table handle and query config from FFI arguments, then execute the query.
-/// Returns the runtime and record batch stream on success, or a SimpleResult error.
+/// Parse the `order_by` config key, a list of
+/// {"column", "descending", "nulls_first"} sort keys.
+fn parse_order_by(order_by: &serde_json::Value) -> Result<Vec<ColumnOrdering>, lancedb::Error> {
+    let keys = order_by
+        .as_array()
+        .ok_or_else(|| lancedb::Error::InvalidInput {
+            message: "order_by must be an array of sort keys".to_string(),
+        })?;
+    let mut ordering = Vec::with_capacity(keys.len());
+    for (idx, key) in keys.iter().enumerate() {
+        let column = key
+            .get("column")
+            .and_then(|v| v.as_str())
+            .filter(|c| !c.is_empty())
+            .ok_or_else(|| lancedb::Error::InvalidInput {
+                message: format!("order_by #{}: `column` must be a non-empty string", idx),
+            })?
+            .to_string();
+        let descending = key
+            .get("descending")
+            .and_then(|v| v.as_bool())
+            .unwrap_or(false);
+        let nulls_first = key
+            .get("nulls_first")
+            .and_then(|v| v.as_bool())
+            .unwrap_or(false);
+        ordering.push(match (descending, nulls_first) {
+            (false, false) => ColumnOrdering::asc_nulls_last(column),
+            (false, true) => ColumnOrdering::asc_nulls_first(column),
+            (true, false) => ColumnOrdering::desc_nulls_last(column),
+            (true, true) => ColumnOrdering::desc_nulls_first(column),
+        });
+    }
+    Ok(ordering)
+}
+
+/// Order-preserving u64 keys for a numeric column type: the keys of
+/// the type's smallest and largest values, and the SQL literal a key
+/// stands for. None for types an index-served sort does not handle.
+fn sort_key_domain(data_type: &DataType) -> Option<(u64, u64, fn(u64) -> String)> {
+    let signed = |min: i64, max: i64| -> (u64, u64, fn(u64) -> String) {
+        (i64_key(min), i64_key(max), |k| {
+            ((k ^ SIGN_BIT) as i64).to_string()
+        })
+    };
+    let float = |min: f64, max: f64| -> (u64, u64, fn(u64) -> String) {
+        (f64_key(min), f64_key(max), |k| {
+            let bits = if k & SIGN_BIT != 0 { k ^ SIGN_BIT } else { !k };
+            format!("{:?}", f64::from_bits(bits))
+        })
+    };
+    let unsigned = |max: u64| -> (u64, u64, fn(u64) -> String) { (0, max, |k| k.to_string()) };
+    match data_type {
+        DataType::Int8 => Some(signed(i8::MIN.into(), i8::MAX.into())),
+        DataType::Int16 => Some(signed(i16::MIN.into(), i16::MAX.into())),
+        DataType::Int32 => Some(signed(i32::MIN.into(), i32::MAX.into())),
+        DataType::Int64 => Some(signed(i64::MIN, i64::MAX)),
+        DataType::UInt8 => Some(unsigned(u8::MAX.into())),
+        DataType::UInt16 => Some(unsigned(u16::MAX.into())),
+        DataType::UInt32 => Some(unsigned(u32::MAX.into())),
+        DataType::UInt64 => Some(unsigned(u64::MAX)),
+        DataType::Float32 => Some(float(f32::MIN.into(), f32::MAX.into())),
+        DataType::Float64 => Some(float(f64::MIN, f64::MAX)),
+        _ => None,
+    }
+}
+
+const SIGN_BIT: u64 = 1 << 63;
+
+fn i64_key(v: i64) -> u64 {
+    (v as u64) ^ SIGN_BIT
+}
+
+fn f64_key(v: f64) -> u64 {
+    let bits = v.to_bits();
+    if bits & SIGN_BIT != 0 {
+        !bits
+    } else {
+        bits | SIGN_BIT
+    }
+}
+
+/// Serve a single-key sort with a limit from a BTree index on the key
+/// column: bisect the column's value range with index-only counts for
+/// the tightest bound that still admits the first `needed` rows, and
+/// return it as a filter, so the scan reads and sorts only those rows
+/// and their ties. None when there is no such index, the column is not
+/// numeric, nulls sort first or fewer than `needed` rows are non-null;
+/// the caller then sorts every row.
+async fn index_order_bound(
+    table: &lancedb::Table,
+    dataset: &lance::Dataset,
+    key: &serde_json::Value,
+    needed: u64,
+) -> Result<Option<String>, lancedb::Error> {
+    let Some(column) = key.get("column").and_then(|v| v.as_str()) else {
+        return Ok(None);
+    };
+    let flag = |name: &str| key.get(name).and_then(|v| v.as_bool()).unwrap_or(false);
+    let (descending, nulls_first) = (flag("descending"), flag("nulls_first"));
+    if nulls_first || needed == 0 {
+        return Ok(None);
+    }
+    let Some((mut lo, mut hi, literal)) = dataset
+        .schema()
+        .field(column)
+        .and_then(|f| sort_key_domain(&f.data_type()))
+    else {
+        return Ok(None);
+    };
+    let indexed = table.list_indices().await?.iter().any(|index| {
+        index.index_type == IndexType::BTree
+            && index.columns.len() == 1
+            && index.columns[0] == column
+    });
+    if !indexed {
+        return Ok(None);
+    }
+
+    let op = if descending { ">=" } else { "<=" };
+    let bound = |k: u64| format!("`{}` {} {}", column, op, literal(k));
+    let count = |k: u64| async move {
+        dataset
+            .count_rows(Some(bound(k)))
+            .await
+            .map(|n| n as u64 >= needed)
+    };
+    if descending {
+        // The largest key with at least `needed` rows at or above it.
+        if !count(lo).await? {
+            return Ok(None);
+        }
+        while lo < hi {
+            let mid = lo + (hi - lo) / 2 + (hi - lo) % 2;
+            if count(mid).await? {
+                lo = mid;
+            } else {
+                hi = mid - 1;
+            }
+        }
+        Ok(Some(bound(lo)))
+    } else {
+        // The smallest key with at least `needed` rows at or below it.
+        if !count(hi).await? {
+            return Ok(None);
+        }
+        while lo < hi {
+            let mid = lo + (hi - lo) / 2;
+            if count(mid).await? {
+                hi = mid;
+            } else {
+                lo = mid + 1;
+            }
+        }
+        Ok(Some(bound(hi)))
+    }
+}
+
+/// Run a plain query on a lance Scanner, for the options lancedb's
+/// Query lacks: `fragment_ids` restricts the scan to those fragments
+/// (an empty list yields no rows, with the projected schema) and
+/// `order_by` sorts the result. A single numeric sort key with a limit,
+/// no `where` and no `fragment_ids` is bounded through the key's BTree
+/// index when it has one (see index_order_bound); otherwise every
+/// filtered row is sorted (a top-k sort with a limit). The other
+/// supported options are columns, select_exprs, where, limit, offset
+/// and with_row_id. The dataset is read at the handle's version and
+/// the stream is adapted to the same type the other query paths return.
+async fn scan_native(
+    table: &lancedb::Table,
+    query_config: &serde_json::Value,
+) -> Result<lancedb::arrow::SendableRecordBatchStream, lancedb::Error> {
+    let dataset = open_native_dataset(table)
+        .await
+        .map_err(|message| lancedb::Error::Runtime { message })?;
+
+    let mut scanner = dataset.scan();
+    let mut no_fragments = false;
+    if let Some(ids) = query_config.get("fragment_ids").and_then(|v| v.as_array()) {
+        no_fragments = ids.is_empty();
+        let mut fragments = Vec::with_capacity(ids.len());
+        for id in ids {
+            let id = id.as_u64().ok_or_else(|| lancedb::Error::InvalidInput {
+                message: format!("Invalid fragment id: {}", id),
+            })?;
+            let fragment =
+                dataset
+                    .get_fragment(id as usize)
+                    .ok_or_else(|| lancedb::Error::InvalidInput {
+                        message: format!("fragment {} not found", id),
+                    })?;
+            fragments.push(fragment.metadata().clone());
+        }
+        scanner.with_fragments(fragments);
+    }
+    let limit = query_config
+        .get("limit")
+        .and_then(|v| v.as_u64())
+        .map(|n| n as i64);
+    let offset = query_config
+        .get("offset")
+        .and_then(|v| v.as_u64())
+        .map(|n| n as i64);
+    let mut filter = query_config
+        .get("where")
+        .and_then(|v| v.as_str())
+        .map(|f| f.to_string());
+    if let Some(order_by) = query_config.get("order_by") {
+        let ordering = parse_order_by(order_by)?;
+        if let (Some(limit), [key], None, false) = (
+            limit,
+            order_by.as_array().map(Vec::as_slice).unwrap_or_default(),
+            &filter,
+            query_config
+                .get("fragment_ids")
+                .is_some_and(|v| !v.is_null()),
+        ) {
+            let needed = (limit + offset.unwrap_or(0)) as u64;
+            filter = index_order_bound(table, &dataset, key, needed).await?;
+        }
+        if !ordering.is_empty() {
+            scanner.order_by(Some(ordering))?;
+        }
+    }
+    match parse_select(query_config)? {
+        Some(lancedb::query::Select::Columns(column_names)) => {
+            scanner.project(&column_names)?;
+        }
+        Some(lancedb::query::Select::Dynamic(pairs)) => {
+            scanner.project_with_transform(&pairs)?;
+        }
+        _ => {}
+    }
+    if let Some(filter) = &filter {
+        scanner.filter(filter)?;
+    }
+    if limit.is_some() || offset.is_some() {
+        scanner.limit(limit, offset)?;
+    }
+    if query_config
+        .get("with_row_id")
+        .and_then(|v| v.as_bool())
+        .unwrap_or(false)
+    {
+        scanner.with_row_id();
+    }
+
+    let schema = scanner.schema().await?;
+    if no_fragments {
+        return Ok(Box::pin(lancedb::arrow::SimpleRecordBatchStream::new(
+            tokio_stream::empty(),
+            schema,
+        )));
+    }
+    let stream = scanner.try_into_stream().await?;
+    let stream = stream.map(|batch| batch.map_err(lancedb::Error::from));
+    Ok(Box::pin(lancedb::arrow::SimpleRecordBatchStream::new(
+        stream, schema,
+    )))
+}
+
+/// Marks a query that ran past its `timeout_ms`. The Go bindings look
+/// for this text to return a typed timeout error.
+pub(crate) const QUERY_TIMEOUT_ERROR: &str = "query timed out";
+
+/// The deadline set by the config's optional `timeout_ms`, counted from
+/// now. It covers planning and reading every result batch.
+fn query_deadline(query_config: &serde_json::Value) -> Option<tokio::time::Instant> {
+    query_config
+        .get("timeout_ms")
+        .and_then(|v| v.as_u64())
+        .filter(|ms| *ms > 0)
+        .map(|ms| tokio::time::Instant::now() + std::time::Duration::from_millis(ms))
+}
+
+/// Await `fut`, dropping it (which cancels the query) and failing with
+/// QUERY_TIMEOUT_ERROR once `deadline` passes.
+async fn before_deadline<T>(
+    deadline: Option<tokio::time::Instant>,
+    fut: impl std::future::Future<Output = Result<T, lancedb::Error>>,
+) -> Result<T, lancedb::Error> {
+    match deadline {
+        None => fut.await,
+        Some(deadline) => tokio::time::timeout_at(deadline, fut)
+            .await
+            .unwrap_or_else(|_| {
+                Err(lancedb::Error::Runtime {
+                    message: QUERY_TIMEOUT_ERROR.to_string(),
+                })
+            }),
+    }
+}
+
+/// Parse table handle, query config and optional Arrow query vector from
+/// FFI arguments, then execute the query. A null `vector_ipc_data` (or a
+/// zero `vector_ipc_len`) leaves the vector branch reading the JSON
+/// `vector` field. Returns the runtime, the query deadline and the
+/// record batch stream on success, or a SimpleResult error.
+#[allow(clippy::type_complexity)]
 fn parse_and_execute(
     table_handle: *mut c_void,
     query_config_json: *const c_char,
+    vector_ipc_data: *const u8,
+    vector_ipc_len: usize,
 ) -> Result<
     (
         std::sync::Arc<tokio::runtime::Runtime>,
+        Option<tokio::time::Instant>,
         impl tokio_stream::Stream<Item = Result<arrow_array::RecordBatch, lancedb::Error>>,
     ),
     SimpleResult,
@@ -358,6 +799,16 @@ fn parse_and_execute(
         }
     };
 
+    let query_vector = if vector_ipc_data.is_null() || vector_ipc_len == 0 {
+        None
+    } else {
+        let bytes = unsafe { std::slice::from_raw_parts(vector_ipc_data, vector_ipc_len) };
+        match decode_query_vector(bytes) {
+            Ok(v) => Some(v),
+            Err(e) => return Err(SimpleResult::error(format!("Invalid query vector: {}", e))),
+        }
+    };
+
     let table = unsafe { &*(table_handle as *const lancedb::Table) };
     let rt = get_simple_runtime();
 
@@ -371,8 +822,12 @@ fn parse_and_execute(
         }
     };
 
-    match rt.block_on(execute_query_from_config(table, &query_config)) {
-        Ok(stream) => Ok((rt, stream)),
+    let deadline = query_deadline(&query_config);
+    match rt.block_on(before_deadline(
+        deadline,
+        execute_query_from_config(table, &query_config, query_vector),
+    )) {
+        Ok(stream) => Ok((rt, deadline, stream)),
         Err(e) => Err(SimpleResult::error(format!(
             "Failed to execute query: {}",
             e
@@ -380,70 +835,185 @@ fn parse_and_execute(
     }
 }
 
-/// Execute a select query and return results as JSON.
-#[no_mangle]
-#[allow(clippy::not_unsafe_ptr_arg_deref)]
-pub extern "C" fn simple_lancedb_table_select_query(
+/// Shared body of the JSON select entry points. Runs the query and
+/// serializes every row into a JSON array written to `result_json`.
+fn select_query_to_json(
     table_handle: *mut c_void,
     query_config_json: *const c_char,
+    vector_ipc_data: *const u8,
+    vector_ipc_len: usize,
     result_json: *mut *mut c_char,
-) -> *mut SimpleResult {
-    let result = std::panic::catch_unwind(|| -> SimpleResult {
-        if table_handle.is_null() || query_config_json.is_null() || result_json.is_null() {
-            return SimpleResult::error("Invalid null arguments".to_string());
-        }
+) -> SimpleResult {
+    if table_handle.is_null() || query_config_json.is_null() || result_json.is_null() {
+        return SimpleResult::error("Invalid null arguments".to_string());
+    }
 
-        let (rt, stream) = match parse_and_execute(table_handle, query_config_json) {
-            Ok(v) => v,
-            Err(e) => return e,
-        };
+    let (rt, deadline, stream) = match parse_and_execute(
+        table_handle,
+        query_config_json,
+        vector_ipc_data,
+        vector_ipc_len,
+    ) {
+        Ok(v) => v,
+        Err(e) => return e,
+    };
 
-        let mut results = Vec::new();
-
-        match rt.block_on(async {
-            let mut stream = stream;
-            while let Some(batch_result) = stream.next().await {
-                match batch_result {
-                    Ok(batch) => {
-                        for row_idx in 0..batch.num_rows() {
-                            let mut row = serde_json::Map::new();
-                            let schema = batch.schema();
-
-                            for (col_idx, field) in schema.fields().iter().enumerate() {
-                                let column = batch.column(col_idx);
-                                let json_value = match convert_arrow_value_to_json(column, row_idx)
-                                {
-                                    Ok(v) => v,
-                                    Err(_) => serde_json::Value::Null,
-                                };
-                                row.insert(field.name().clone(), json_value);
-                            }
-                            results.push(serde_json::Value::Object(row));
+    let mut results = Vec::new();
+
+    match rt.block_on(before_deadline(deadline, async {
+        let mut stream = stream;
+        while let Some(batch_result) = stream.next().await {
+            match batch_result {
+                Ok(batch) => {
+                    for row_idx in 0..batch.num_rows() {
+                        let mut row = serde_json::Map::new();
+                        let schema = batch.schema();
+
+                        for (col_idx, field) in schema.fields().iter().enumerate() {
+                            let column = batch.column(col_idx);
+                            let json_value = match convert_arrow_value_to_json(column, row_idx) {
+                                Ok(v) => v,
+                                Err(_) => serde_json::Value::Null,
+                            };
+                            row.insert(field.name().clone(), json_value);
                         }
+                        results.push(serde_json::Value::Object(row));
                     }
-                    Err(e) => return Err(e),
                 }
+                Err(e) => return Err(e),
             }
-            Ok(())
-        }) {
-            Ok(()) => match serde_json::to_string(&results) {
-                Ok(json_str) => match CString::new(json_str) {
-                    Ok(c_string) => {
-                        unsafe {
-                            *result_json = c_string.into_raw();
-                        }
-                        SimpleResult::ok()
-                    }
-                    Err(_) => {
-                        SimpleResult::error("Failed to convert results to C string".to_string())
+        }
+        Ok(())
+    })) {
+        Ok(()) => match serde_json::to_string(&results) {
+            Ok(json_str) => match CString::new(json_str) {
+                Ok(c_string) => {
+                    unsafe {
+                        *result_json = c_string.into_raw();
                     }
-                },
-                Err(e) => {
-                    SimpleResult::error(format!("Failed to serialize results to JSON: {}", e))
+                    SimpleResult::ok()
                 }
+                Err(_) => SimpleResult::error("Failed to convert results to C string".to_string()),
             },
-            Err(e) => SimpleResult::error(format!("Failed to process query results: {}", e)),
+            Err(e) => SimpleResult::error(format!("Failed to serialize results to JSON: {}", e)),
+        },
+        Err(e) => SimpleResult::error(format!("Failed to process query results: {}", e)),
+    }
+}
+
+/// Serialize `batches` as an Arrow IPC file and hand the buffer to C via
+/// libc::malloc (freed by simple_lancedb_free_ipc_data). The schema is
+/// written even when `batches` is empty so the caller always learns the
+/// result shape.
+pub(crate) fn write_ipc_result(
+    schema: &arrow_schema::SchemaRef,
+    batches: &[arrow_array::RecordBatch],
+    result_ipc_data: *mut *mut u8,
+    result_ipc_len: *mut usize,
+) -> SimpleResult {
+    use arrow_ipc::writer::FileWriter;
+
+    let mut buf = Vec::new();
+    {
+        let mut writer = match FileWriter::try_new(&mut buf, schema) {
+            Ok(w) => w,
+            Err(e) => return SimpleResult::error(format!("Failed to create IPC writer: {}", e)),
+        };
+        for batch in batches {
+            if let Err(e) = writer.write(batch) {
+                return SimpleResult::error(format!("Failed to write IPC batch: {}", e));
+            }
         }
+        if let Err(e) = writer.finish() {
+            return SimpleResult::error(format!("Failed to finish IPC file: {}", e));
+        }
+    }
+
+    // Transfer ownership to C via libc::malloc (freed by simple_lancedb_free_ipc_data)
+    let len = buf.len();
+    let data_ptr = unsafe { libc::malloc(len) as *mut u8 };
+    if data_ptr.is_null() {
+        return SimpleResult::error("Failed to allocate memory for IPC data".to_string());
+    }
+    unsafe {
+        std::ptr::copy_nonoverlapping(buf.as_ptr(), data_ptr, len);
+        *result_ipc_data = data_ptr;
+        *result_ipc_len = len;
+    }
+    SimpleResult::ok()
+}
+
+/// Shared body of the Arrow IPC select entry points. Runs the query and
+/// writes the result batches as an IPC file into a libc-allocated buffer.
+fn select_query_to_ipc(
+    table_handle: *mut c_void,
+    query_config_json: *const c_char,
+    vector_ipc_data: *const u8,
+    vector_ipc_len: usize,
+    result_ipc_data: *mut *mut u8,
+    result_ipc_len: *mut usize,
+) -> SimpleResult {
+    if table_handle.is_null()
+        || query_config_json.is_null()
+        || result_ipc_data.is_null()
+        || result_ipc_len.is_null()
+    {
+        return SimpleResult::error("Invalid null arguments".to_string());
+    }
+
+    let (rt, deadline, stream) = match parse_and_execute(
+        table_handle,
+        query_config_json,
+        vector_ipc_data,
+        vector_ipc_len,
+    ) {
+        Ok(v) => v,
+        Err(e) => return e,
+    };
+
+    match rt.block_on(before_deadline(deadline, async {
+        let mut stream = stream;
+        let mut batches = Vec::new();
+        while let Some(batch_result) = stream.next().await {
+            match batch_result {
+                Ok(batch) => batches.push(batch),
+                Err(e) => return Err(e),
+            }
+        }
+        Ok(batches)
+    })) {
+        Ok(batches) => {
+            if batches.is_empty() {
+                unsafe {
+                    *result_ipc_data = std::ptr::null_mut();
+                    *result_ipc_len = 0;
+                }
+                return SimpleResult::ok();
+            }
+
+            let schema = batches[0].schema();
+            write_ipc_result(&schema, &batches, result_ipc_data, result_ipc_len)
+        }
+        Err(e) => SimpleResult::error(format!("Failed to process query results: {}", e)),
+    }
+}
+
+/// Execute a select query and return results as JSON.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_select_query(
+    table_handle: *mut c_void,
+    query_config_json: *const c_char,
+    result_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        select_query_to_json(
+            table_handle,
+            query_config_json,
+            std::ptr::null(),
+            0,
+            result_json,
+        )
     });
 
     match result {
@@ -454,6 +1024,38 @@ pub extern "C" fn simple_lancedb_table_select_query(
     }
 }
 
+/// Execute a select query and return results as JSON, taking the vector
+/// search query as an Arrow IPC file (one column, one batch) instead of
+/// the JSON `vector` float list. The column keeps its native element
+/// type — Float16, Float32, Float64, Int8, or packed-bit UInt8 for
+/// Hamming search. `vector_ipc_data` may be NULL for non-vector queries.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_select_query_v2(
+    table_handle: *mut c_void,
+    query_config_json: *const c_char,
+    vector_ipc_data: *const u8,
+    vector_ipc_len: usize,
+    result_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        select_query_to_json(
+            table_handle,
+            query_config_json,
+            vector_ipc_data,
+            vector_ipc_len,
+            result_json,
+        )
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_select_query_v2".to_string(),
+        ))),
+    }
+}
+
 /// Execute a select query and return results as Arrow IPC binary data.
 #[no_mangle]
 #[allow(clippy::not_unsafe_ptr_arg_deref)]
@@ -464,89 +1066,52 @@ pub extern "C" fn simple_lancedb_table_select_query_ipc(
     result_ipc_len: *mut usize,
 ) -> *mut SimpleResult {
     let result = std::panic::catch_unwind(|| -> SimpleResult {
-        if table_handle.is_null()
-            || query_config_json.is_null()
-            || result_ipc_data.is_null()
-            || result_ipc_len.is_null()
-        {
-            return SimpleResult::error("Invalid null arguments".to_string());
-        }
-
-        let (rt, stream) = match parse_and_execute(table_handle, query_config_json) {
-            Ok(v) => v,
-            Err(e) => return e,
-        };
-
-        match rt.block_on(async {
-            let mut stream = stream;
-            let mut batches = Vec::new();
-            while let Some(batch_result) = stream.next().await {
-                match batch_result {
-                    Ok(batch) => batches.push(batch),
-                    Err(e) => return Err(e),
-                }
-            }
-            Ok(batches)
-        }) {
-            Ok(batches) => {
-                if batches.is_empty() {
-                    unsafe {
-                        *result_ipc_data = std::ptr::null_mut();
-                        *result_ipc_len = 0;
-                    }
-                    return SimpleResult::ok();
-                }
+        select_query_to_ipc(
+            table_handle,
+            query_config_json,
+            std::ptr::null(),
+            0,
+            result_ipc_data,
+            result_ipc_len,
+        )
+    });
 
-                use arrow_ipc::writer::FileWriter;
-
-                let schema = batches[0].schema();
-                let mut buf = Vec::new();
-                {
-                    let mut writer = match FileWriter::try_new(&mut buf, &schema) {
-                        Ok(w) => w,
-                        Err(e) => {
-                            return SimpleResult::error(format!(
-                                "Failed to create IPC writer: {}",
-                                e
-                            ))
-                        }
-                    };
-                    for batch in &batches {
-                        if let Err(e) = writer.write(batch) {
-                            return SimpleResult::error(format!(
-                                "Failed to write IPC batch: {}",
-                                e
-                            ));
-                        }
-                    }
-                    if let Err(e) = writer.finish() {
-                        return SimpleResult::error(format!("Failed to finish IPC file: {}", e));
-                    }
-                }
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_select_query_ipc".to_string(),
+        ))),
+    }
+}
 
-                // Transfer ownership to C via libc::malloc (freed by simple_lancedb_free_ipc_data)
-                let len = buf.len();
-                let data_ptr = unsafe { libc::malloc(len) as *mut u8 };
-                if data_ptr.is_null() {
-                    return SimpleResult::error(
-                        "Failed to allocate memory for IPC data".to_string(),
-                    );
-                }
-                unsafe {
-                    std::ptr::copy_nonoverlapping(buf.as_ptr(), data_ptr, len);
-                    *result_ipc_data = data_ptr;
-                    *result_ipc_len = len;
-                }
-                SimpleResult::ok()
-            }
-            Err(e) => SimpleResult::error(format!("Failed to process query results: {}", e)),
-        }
+/// Arrow IPC counterpart of simple_lancedb_table_select_query_v2: the
+/// query vector arrives as an Arrow IPC file and the results leave as
+/// one. `vector_ipc_data` may be NULL for non-vector queries.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_select_query_ipc_v2(
+    table_handle: *mut c_void,
+    query_config_json: *const c_char,
+    vector_ipc_data: *const u8,
+    vector_ipc_len: usize,
+    result_ipc_data: *mut *mut u8,
+    result_ipc_len: *mut usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        select_query_to_ipc(
+            table_handle,
+            query_config_json,
+            vector_ipc_data,
+            vector_ipc_len,
+            result_ipc_data,
+            result_ipc_len,
+        )
     });
 
     match result {
         Ok(res) => Box::into_raw(Box::new(res)),
         Err(_) => Box::into_raw(Box::new(SimpleResult::error(
-            "Panic in simple_lancedb_table_select_query_ipc".to_string(),
+            "Panic in simple_lancedb_table_select_query_ipc_v2".to_string(),
         ))),
     }
 }
@@ -570,6 +1135,26 @@ mod tests {
         assert!(r.is_none() && n.is_none(), "explicit null reranker");
     }
 
+    #[test]
+    fn parse_select_quotes_plain_columns() {
+        let config = serde_json::json!({
+            "columns": ["order", "Title", "meta.a`b"],
+            "select_exprs": [{"alias": "n", "expr": "`order` + 1"}],
+        });
+        match parse_select(&config).unwrap() {
+            Some(lancedb::query::Select::Dynamic(pairs)) => assert_eq!(
+                pairs,
+                vec![
+                    ("order".to_string(), "`order`".to_string()),
+                    ("Title".to_string(), "`Title`".to_string()),
+                    ("meta.a`b".to_string(), "`meta`.`a``b`".to_string()),
+                    ("n".to_string(), "`order` + 1".to_string()),
+                ]
+            ),
+            _ => panic!("expected a dynamic projection"),
+        }
+    }
+
     #[test]
     fn parse_reranker_rejects_unknown_kind() {
         let bad = serde_json::json!({"reranker": {"kind": "what"}});
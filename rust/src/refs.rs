@@ -10,10 +10,14 @@
 //! lance::dataset::refs::TagContents serializes camelCase, which would
 //! be a silent footgun for Go callers.
 
+use crate::dataset::open_latest_dataset;
 use crate::ffi::{from_c_str, SimpleResult};
 use crate::runtime::get_simple_runtime;
+use lance::dataset::transaction::{Operation, Transaction};
+use lance::dataset::CommitBuilder;
 use std::ffi::CString;
 use std::os::raw::{c_char, c_void};
+use std::sync::Arc;
 
 /// List every version reachable from the dataset. Returns a JSON array
 /// of {version, timestamp, metadata} objects ordered as reported by the
@@ -74,6 +78,96 @@ pub extern "C" fn simple_lancedb_table_list_versions(
     }
 }
 
+/// Classify a commit by its lance transaction operation. merge_insert
+/// and update both commit Operation::Update; only merge_insert rewrites
+/// columns in place (UpdateMode::RewriteColumns), so those commits
+/// report "merge". A merge_insert that rewrites whole rows leaves the
+/// same transaction as an update and reports "update". Lance's
+/// Operation::Merge adds columns from data and reports "add_columns".
+fn operation_kind(op: &Operation) -> &'static str {
+    use lance::dataset::transaction::UpdateMode;
+    match op {
+        Operation::Append { .. } => "append",
+        Operation::Delete { .. } => "delete",
+        Operation::Update {
+            update_mode: Some(UpdateMode::RewriteColumns),
+            ..
+        } => "merge",
+        Operation::Update { .. } => "update",
+        Operation::Merge { .. } => "add_columns",
+        Operation::CreateIndex { .. } => "index",
+        Operation::Rewrite { .. } => "compaction",
+        Operation::Overwrite { .. } => "overwrite",
+        Operation::Restore { .. } => "restore",
+        Operation::Project { .. } => "schema",
+        _ => "other",
+    }
+}
+
+/// List versions newer than `since_version` from the latest manifest,
+/// including commits made by other writers since this handle was
+/// opened. Each entry is {version, timestamp, metadata, operation}.
+/// Caller owns events_json and must free it with
+/// simple_lancedb_free_string.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_version_events(
+    table_handle: *mut c_void,
+    since_version: u64,
+    events_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || events_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+
+        match rt.block_on(async {
+            let dataset = open_latest_dataset(table).await?;
+            let versions = dataset.versions().await.map_err(|e| e.to_string())?;
+            let mut events = Vec::new();
+            for v in versions.into_iter().filter(|v| v.version > since_version) {
+                // A missing transaction file (very old datasets) is not
+                // an error; the commit is reported as "other".
+                let operation = match dataset.read_transaction_by_version(v.version).await {
+                    Ok(Some(tx)) => operation_kind(&tx.operation),
+                    _ => "other",
+                };
+                events.push(serde_json::json!({
+                    "version": v.version,
+                    "timestamp": v.timestamp.to_rfc3339(),
+                    "metadata": v.metadata.into_iter().collect::<std::collections::BTreeMap<_, _>>(),
+                    "operation": operation,
+                }));
+            }
+            Ok::<_, String>(events)
+        }) {
+            Ok(events) => match serde_json::to_string(&events) {
+                Ok(json_str) => match CString::new(json_str) {
+                    Ok(c_string) => {
+                        unsafe {
+                            *events_json = c_string.into_raw();
+                        }
+                        SimpleResult::ok()
+                    }
+                    Err(_) => SimpleResult::error("Failed to convert JSON to C string".to_string()),
+                },
+                Err(e) => SimpleResult::error(format!("Failed to serialize version events: {}", e)),
+            },
+            Err(e) => SimpleResult::error(format!("Failed to list version events: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_version_events".to_string(),
+        ))),
+    }
+}
+
 /// Pin the table to a specific version. Subsequent reads see that
 /// snapshot; writes are rejected until the table is brought back with
 /// either checkout_latest or restore. Mirrors lancedb::Table::checkout.
@@ -188,6 +282,103 @@ pub extern "C" fn simple_lancedb_table_restore(table_handle: *mut c_void) -> *mu
     }
 }
 
+/// Restore `version` (or the version `tag` points to, when `tag` is
+/// non-null) as a new latest version in a single call. The latest
+/// version is read first and written to *observed_version_out; when
+/// `expected_version` is non-zero and does not match, nothing is
+/// committed and an error is returned. The restore is then committed
+/// as a transaction read at that version. Lance never rejects a
+/// restore, so the committed version is checked instead: when it is
+/// not observed + 1, another writer committed in between and was
+/// superseded by the restore, and an error is returned with
+/// *observed_version_out set to the version the restore replaced. In
+/// both the success and the lost-race case the new version number is
+/// written to *new_version_out and the handle is left tracking latest.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_restore_to(
+    table_handle: *mut c_void,
+    version: u64,
+    tag: *const c_char,
+    expected_version: u64,
+    observed_version_out: *mut u64,
+    new_version_out: *mut u64,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || observed_version_out.is_null() || new_version_out.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let tag_str = if tag.is_null() {
+            if version == 0 {
+                return SimpleResult::error("a version or tag to restore is required".to_string());
+            }
+            None
+        } else {
+            match from_c_str(tag) {
+                Ok(s) => Some(s),
+                Err(e) => return SimpleResult::error(format!("Invalid tag: {}", e)),
+            }
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_latest_dataset(table).await?;
+            let current = dataset.version().version;
+            unsafe {
+                *observed_version_out = current;
+            }
+            if expected_version != 0 && current != expected_version {
+                return Err(format!(
+                    "expected current version {}, found {}",
+                    expected_version, current
+                ));
+            }
+
+            let target = match &tag_str {
+                Some(t) => {
+                    let tags = table.tags().await.map_err(|e| e.to_string())?;
+                    tags.get_version(t).await.map_err(|e| e.to_string())?
+                }
+                None => version,
+            };
+            let transaction =
+                Transaction::new(current, Operation::Restore { version: target }, None);
+            let committed = CommitBuilder::new(Arc::new(dataset))
+                .execute(transaction)
+                .await
+                .map_err(|e| e.to_string())?;
+            let new_version = committed.version().version;
+            unsafe {
+                *new_version_out = new_version;
+            }
+            table.checkout_latest().await.map_err(|e| e.to_string())?;
+            if expected_version != 0 && new_version != current + 1 {
+                unsafe {
+                    *observed_version_out = new_version - 1;
+                }
+                return Err(format!(
+                    "restore committed as version {} on top of version {}, not {}",
+                    new_version,
+                    new_version - 1,
+                    current
+                ));
+            }
+            Ok(new_version)
+        }) {
+            Ok(_) => SimpleResult::ok(),
+            Err(e) => SimpleResult::error(format!("restore failed: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_restore_to".to_string(),
+        ))),
+    }
+}
+
 /// List every tag on the table as a JSON object keyed by tag name.
 /// Each value carries the pinned version, manifest_size, and an
 /// optional branch (currently always absent for tags created via the
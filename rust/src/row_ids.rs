@@ -0,0 +1,364 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Mutations scoped to a set of row IDs.
+//!
+//! The row IDs arrive as an Arrow IPC file with a single non-null
+//! UInt64 column (the `_rowid` values of an earlier query), never as
+//! SQL. Both mutations resolve the IDs to row addresses with take_rows
+//! and extend the fragments' deletion vectors directly, so no scan or
+//! predicate is involved. Updates evaluate the assignments over the
+//! taken rows, write them to new fragments and commit both halves as
+//! one Operation::Update, the way lance's own update does; on tables
+//! with stable row IDs the rewritten rows keep their IDs.
+
+use crate::data::{ipc_to_record_batches, parse_assignments};
+use crate::dataset::open_writable_dataset;
+use crate::ffi::{from_c_str, SimpleResult};
+use crate::runtime::get_simple_runtime;
+use arrow_array::{Array, RecordBatch, UInt64Array};
+use arrow_schema::Schema as ArrowSchema;
+use lance::dataset::transaction::{Operation, Transaction, UpdateMode};
+use lance::dataset::{CommitBuilder, InsertBuilder, ProjectionRequest, WriteMode, WriteParams};
+use lance::io::exec::Planner;
+use lance::Dataset;
+use lance_table::format::{Fragment, RowIdMeta};
+use lance_table::rowids::{write_row_ids, RowIdSequence};
+use std::collections::{BTreeMap, BTreeSet};
+use std::ffi::CString;
+use std::os::raw::{c_char, c_void};
+use std::sync::Arc;
+
+const ROW_ID_COLUMN: &str = "_rowid";
+const ROW_ADDR_COLUMN: &str = "_rowaddr";
+
+/// Decode the row ID payload into a sorted, de-duplicated list.
+fn decode_row_ids(data: *const u8, len: usize) -> Result<Vec<u64>, String> {
+    let ipc_bytes = unsafe { std::slice::from_raw_parts(data, len) };
+    let mut ids = BTreeSet::new();
+    for batch in ipc_to_record_batches(ipc_bytes)? {
+        if batch.num_columns() != 1 {
+            return Err(format!(
+                "row ID payload must carry exactly one column, got {}",
+                batch.num_columns()
+            ));
+        }
+        let column = batch
+            .column(0)
+            .as_any()
+            .downcast_ref::<UInt64Array>()
+            .ok_or_else(|| {
+                format!(
+                    "row ID column must be UInt64, got {}",
+                    batch.column(0).data_type()
+                )
+            })?;
+        if column.null_count() > 0 {
+            return Err("row IDs must not contain nulls".to_string());
+        }
+        ids.extend(column.values().iter().copied());
+    }
+    if ids.is_empty() {
+        return Err("at least one row ID must be specified".to_string());
+    }
+    Ok(ids.into_iter().collect())
+}
+
+fn write_json_result(value: serde_json::Value, result_json: *mut *mut c_char) -> SimpleResult {
+    match CString::new(value.to_string()) {
+        Ok(c) => {
+            unsafe {
+                *result_json = c.into_raw();
+            }
+            SimpleResult::ok()
+        }
+        Err(_) => SimpleResult::error("Failed to convert JSON to C string".to_string()),
+    }
+}
+
+fn u64_column<'a>(batch: &'a RecordBatch, name: &str) -> Result<&'a UInt64Array, String> {
+    batch
+        .column_by_name(name)
+        .and_then(|c| c.as_any().downcast_ref::<UInt64Array>())
+        .ok_or_else(|| format!("take did not return {}", name))
+}
+
+/// Extend the deletion vectors of `dataset` with the row addresses
+/// `addrs`. Returns the rewritten fragments and the IDs of fragments
+/// left with no live rows, ready for an Operation::Delete or
+/// Operation::Update.
+async fn delete_addresses(
+    dataset: &Dataset,
+    addrs: &[u64],
+) -> Result<(Vec<Fragment>, Vec<u64>), String> {
+    let mut by_fragment: BTreeMap<u32, Vec<u32>> = BTreeMap::new();
+    for addr in addrs {
+        by_fragment
+            .entry((addr >> 32) as u32)
+            .or_default()
+            .push(*addr as u32);
+    }
+
+    let mut updated_fragments = Vec::new();
+    let mut removed_fragment_ids = Vec::new();
+    for (fragment_id, offsets) in by_fragment {
+        let fragment = dataset
+            .get_fragment(fragment_id as usize)
+            .ok_or_else(|| format!("fragment {} not found", fragment_id))?;
+        match fragment
+            .extend_deletions(offsets)
+            .await
+            .map_err(|e| e.to_string())?
+        {
+            Some(updated) => updated_fragments.push(updated.metadata().clone()),
+            None => removed_fragment_ids.push(fragment_id as u64),
+        }
+    }
+    Ok((updated_fragments, removed_fragment_ids))
+}
+
+/// Delete the rows whose `_rowid` is listed in `row_ids_ipc` from the
+/// latest version of the table, in one commit. rows_deleted counts
+/// only rows that were live before the commit. On success *result_json
+/// is set to `{"rows_deleted": <u64>, "version": <u64>}` (free with
+/// simple_lancedb_free_string) and the handle moves to the new version.
+/// Refused while the handle has a version checked out.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_delete_row_ids(
+    table_handle: *mut c_void,
+    row_ids_ipc: *const u8,
+    row_ids_len: usize,
+    result_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || row_ids_ipc.is_null() || result_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let ids = match decode_row_ids(row_ids_ipc, row_ids_len) {
+            Ok(ids) => ids,
+            Err(e) => return SimpleResult::error(format!("Invalid row IDs: {}", e)),
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_writable_dataset(table).await?;
+            let rows_before = dataset.count_rows(None).await.map_err(|e| e.to_string())?;
+
+            // Row IDs equal row addresses unless the table uses stable
+            // row IDs; taking _rowaddr resolves both cases.
+            let projection = ProjectionRequest::from_columns([ROW_ADDR_COLUMN], dataset.schema());
+            let batch = dataset
+                .take_rows(&ids, projection)
+                .await
+                .map_err(|e| e.to_string())?;
+            let addrs = u64_column(&batch, ROW_ADDR_COLUMN)?;
+            let (updated_fragments, deleted_fragment_ids) =
+                delete_addresses(&dataset, addrs.values()).await?;
+
+            let read_version = dataset.version().version;
+            let transaction = Transaction::new(
+                read_version,
+                Operation::Delete {
+                    updated_fragments,
+                    deleted_fragment_ids,
+                    predicate: format!("delete {} row IDs", ids.len()),
+                },
+                None,
+            );
+            let committed = CommitBuilder::new(Arc::new(dataset))
+                .execute(transaction)
+                .await
+                .map_err(|e| e.to_string())?;
+            let rows_after = committed
+                .count_rows(None)
+                .await
+                .map_err(|e| e.to_string())?;
+            table.checkout_latest().await.map_err(|e| e.to_string())?;
+            Ok::<_, String>((
+                rows_before.saturating_sub(rows_after) as u64,
+                committed.version().version,
+            ))
+        }) {
+            Ok((rows_deleted, version)) => write_json_result(
+                serde_json::json!({"rows_deleted": rows_deleted, "version": version}),
+                result_json,
+            ),
+            Err(e) => SimpleResult::error(format!("Failed to delete rows: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_delete_row_ids".to_string(),
+        ))),
+    }
+}
+
+/// Rewrite the rows `ids` of `dataset` with `assignments` applied, as
+/// one Operation::Update. Each expression is evaluated over the rows as
+/// they were before the update and cast to its column's type. Returns
+/// the number of rows updated and the new version; nothing is committed
+/// when none of the IDs is live.
+async fn update_rows(
+    dataset: Dataset,
+    ids: &[u64],
+    assignments: Vec<(String, String)>,
+) -> Result<(u64, u64), String> {
+    let schema = Arc::new(ArrowSchema::from(dataset.schema()));
+    let mut columns: Vec<&str> = schema.fields().iter().map(|f| f.name().as_str()).collect();
+    columns.push(ROW_ID_COLUMN);
+    columns.push(ROW_ADDR_COLUMN);
+    let projection = ProjectionRequest::from_columns(columns, dataset.schema());
+    let taken = dataset
+        .take_rows(ids, projection)
+        .await
+        .map_err(|e| e.to_string())?;
+    let num_rows = taken.num_rows();
+    if num_rows == 0 {
+        return Ok((0, dataset.version().version));
+    }
+    let row_ids = u64_column(&taken, ROW_ID_COLUMN)?.clone();
+    let addrs = u64_column(&taken, ROW_ADDR_COLUMN)?.clone();
+
+    let mut values = Vec::with_capacity(schema.fields().len());
+    for field in schema.fields() {
+        let column = taken
+            .column_by_name(field.name())
+            .ok_or_else(|| format!("take did not return column {}", field.name()))?;
+        values.push(column.clone());
+    }
+    let before = RecordBatch::try_new(schema.clone(), values.clone()).map_err(|e| e.to_string())?;
+
+    let planner = Planner::new(schema.clone());
+    for (column, expr) in assignments {
+        let idx = schema
+            .index_of(&column)
+            .map_err(|_| format!("column {} not found", column))?;
+        let logical = planner
+            .parse_expr(&expr)
+            .and_then(|e| planner.optimize_expr(e))
+            .map_err(|e| format!("invalid expression for {}: {}", column, e))?;
+        let physical = planner
+            .create_physical_expr(&logical)
+            .map_err(|e| format!("invalid expression for {}: {}", column, e))?;
+        let value = physical
+            .evaluate(&before)
+            .and_then(|v| v.into_array(num_rows))
+            .map_err(|e| format!("failed to evaluate {}: {}", column, e))?;
+        values[idx] = arrow_cast::cast(&value, schema.field(idx).data_type())
+            .map_err(|e| format!("failed to cast {}: {}", column, e))?;
+    }
+    let after = RecordBatch::try_new(schema, values).map_err(|e| e.to_string())?;
+
+    let params = WriteParams {
+        mode: WriteMode::Append,
+        ..Default::default()
+    };
+    let written = InsertBuilder::new(Arc::new(dataset.clone()))
+        .with_params(&params)
+        .execute_uncommitted(vec![after])
+        .await
+        .map_err(|e| e.to_string())?;
+    let mut new_fragments = match written.operation {
+        Operation::Append { fragments } => fragments,
+        _ => return Err("update did not write new fragments".to_string()),
+    };
+    // The rewritten rows keep their stable row IDs; the writer keeps
+    // row order, so the IDs are assigned to the new fragments in turn.
+    if dataset.manifest().uses_stable_row_ids() {
+        let mut offset = 0;
+        for fragment in new_fragments.iter_mut() {
+            let n = fragment
+                .physical_rows
+                .ok_or_else(|| "written fragment has no row count".to_string())?;
+            let sequence = RowIdSequence::from(&row_ids.values()[offset..offset + n]);
+            fragment.row_id_meta = Some(RowIdMeta::Inline(write_row_ids(&sequence)));
+            offset += n;
+        }
+    }
+
+    let (updated_fragments, removed_fragment_ids) =
+        delete_addresses(&dataset, addrs.values()).await?;
+    let transaction = Transaction::new(
+        dataset.version().version,
+        Operation::Update {
+            removed_fragment_ids,
+            updated_fragments,
+            new_fragments,
+            fields_modified: vec![],
+            mem_wal_to_merge: None,
+            fields_for_preserving_frag_bitmap: vec![],
+            update_mode: Some(UpdateMode::RewriteRows),
+        },
+        None,
+    );
+    let committed = CommitBuilder::new(Arc::new(dataset))
+        .execute(transaction)
+        .await
+        .map_err(|e| e.to_string())?;
+    Ok((num_rows as u64, committed.version().version))
+}
+
+/// Update the rows whose `_rowid` is listed in `row_ids_ipc` using raw
+/// SQL expressions per column, in one commit against the latest
+/// version. `assignments_json` has the same shape as for
+/// simple_lancedb_table_update_expr. The IDs are resolved with
+/// take_rows, never turned into a filter. On success *result_json is
+/// set to `{"rows_updated": <u64>, "version": <u64>}` (free with
+/// simple_lancedb_free_string) and the handle moves to the new version.
+/// Refused while the handle has a version checked out.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_update_row_ids(
+    table_handle: *mut c_void,
+    row_ids_ipc: *const u8,
+    row_ids_len: usize,
+    assignments_json: *const c_char,
+    result_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null()
+            || row_ids_ipc.is_null()
+            || assignments_json.is_null()
+            || result_json.is_null()
+        {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let ids = match decode_row_ids(row_ids_ipc, row_ids_len) {
+            Ok(ids) => ids,
+            Err(e) => return SimpleResult::error(format!("Invalid row IDs: {}", e)),
+        };
+        let pairs = match from_c_str(assignments_json)
+            .map_err(|e| format!("Invalid assignments JSON: {}", e))
+            .and_then(|s| parse_assignments(&s))
+        {
+            Ok(p) => p,
+            Err(e) => return SimpleResult::error(e),
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let dataset = open_writable_dataset(table).await?;
+            let updated = update_rows(dataset, &ids, pairs).await?;
+            table.checkout_latest().await.map_err(|e| e.to_string())?;
+            Ok::<_, String>(updated)
+        }) {
+            Ok((rows_updated, version)) => write_json_result(
+                serde_json::json!({"rows_updated": rows_updated, "version": version}),
+                result_json,
+            ),
+            Err(e) => SimpleResult::error(format!("Failed to update rows: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_update_row_ids".to_string(),
+        ))),
+    }
+}
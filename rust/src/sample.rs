@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Seeded uniform samples of a table.
+//!
+//! Like lance's Dataset::sample, rows are drawn without replacement by
+//! picking random row offsets and fetching only those rows with
+//! Dataset::take, so the table is never scanned in full. With a filter
+//! the population is the matching rows: their row IDs are collected by
+//! a scan that reads no columns, and the sample is fetched with
+//! Dataset::take_rows. The RNG is seeded by the caller, so the same
+//! seed and table version always give the same sample.
+
+use crate::dataset::open_native_dataset;
+use crate::ffi::{from_c_str, SimpleResult};
+use crate::query::write_ipc_result;
+use crate::runtime::get_simple_runtime;
+use crate::take::parse_columns;
+use arrow_array::cast::AsArray;
+use arrow_array::types::UInt64Type;
+use arrow_array::RecordBatch;
+use lance::dataset::ProjectionRequest;
+use rand::rngs::StdRng;
+use rand::SeedableRng;
+use std::os::raw::{c_char, c_void};
+use tokio_stream::StreamExt;
+
+/// Pick min(n, population) distinct positions in [0, population), in
+/// ascending order so the rows are fetched in storage order.
+fn sample_positions(population: usize, n: usize, seed: u64) -> Vec<usize> {
+    if n >= population {
+        return (0..population).collect();
+    }
+    let mut rng = StdRng::seed_from_u64(seed);
+    let mut positions = rand::seq::index::sample(&mut rng, population, n).into_vec();
+    positions.sort_unstable();
+    positions
+}
+
+async fn sample_batch(
+    table: &lancedb::Table,
+    n: usize,
+    seed: u64,
+    columns: &[String],
+    filter: Option<String>,
+) -> Result<RecordBatch, String> {
+    let dataset = open_native_dataset(table).await?;
+    let projection = if columns.is_empty() {
+        dataset.schema().clone()
+    } else {
+        dataset
+            .schema()
+            .project(columns)
+            .map_err(|e| e.to_string())?
+    };
+    let projection = ProjectionRequest::from_schema(projection);
+
+    let Some(filter) = filter else {
+        let population = dataset.count_rows(None).await.map_err(|e| e.to_string())?;
+        let offsets: Vec<u64> = sample_positions(population, n, seed)
+            .into_iter()
+            .map(|p| p as u64)
+            .collect();
+        return dataset
+            .take(&offsets, projection)
+            .await
+            .map_err(|e| e.to_string());
+    };
+
+    let mut scanner = dataset.scan();
+    scanner
+        .project::<&str>(&[])
+        .map_err(|e| e.to_string())?
+        .with_row_id()
+        .filter(&filter)
+        .map_err(|e| e.to_string())?;
+    let mut stream = scanner.try_into_stream().await.map_err(|e| e.to_string())?;
+    let mut row_ids = Vec::new();
+    while let Some(batch) = stream.next().await {
+        let batch = batch.map_err(|e| e.to_string())?;
+        let ids = batch
+            .column_by_name("_rowid")
+            .ok_or_else(|| "filter scan returned no _rowid column".to_string())?;
+        row_ids.extend_from_slice(ids.as_primitive::<UInt64Type>().values());
+    }
+    let ids: Vec<u64> = sample_positions(row_ids.len(), n, seed)
+        .into_iter()
+        .map(|p| row_ids[p])
+        .collect();
+    dataset
+        .take_rows(&ids, projection)
+        .await
+        .map_err(|e| e.to_string())
+}
+
+/// Draw up to `n` rows uniformly at random, without replacement, from
+/// the version the table handle sees and return them in storage order
+/// as an Arrow IPC file. Every row is returned when the population has
+/// at most `n` rows. `columns_json` is a JSON array of column names, or
+/// null for every column; `filter`, when not null, restricts the
+/// population to the matching rows. The same seed and version give the
+/// same sample. The IPC buffer is freed with
+/// simple_lancedb_free_ipc_data.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_sample(
+    table_handle: *mut c_void,
+    n: usize,
+    seed: u64,
+    columns_json: *const c_char,
+    filter: *const c_char,
+    result_ipc_data: *mut *mut u8,
+    result_ipc_len: *mut usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || result_ipc_data.is_null() || result_ipc_len.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let columns = match parse_columns(columns_json) {
+            Ok(c) => c,
+            Err(e) => return SimpleResult::error(format!("Invalid columns_json: {}", e)),
+        };
+        let filter = if filter.is_null() {
+            None
+        } else {
+            match from_c_str(filter) {
+                Ok(f) => Some(f),
+                Err(e) => return SimpleResult::error(format!("Invalid filter: {}", e)),
+            }
+        };
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+        match rt.block_on(sample_batch(table, n, seed, &columns, filter)) {
+            Ok(batch) => {
+                let schema = batch.schema();
+                write_ipc_result(&schema, &[batch], result_ipc_data, result_ipc_len)
+            }
+            Err(e) => SimpleResult::error(format!("Failed to sample table: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_sample".to_string(),
+        ))),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn sample_positions_are_seeded_and_distinct() {
+        let a = sample_positions(1000, 10, 42);
+        assert_eq!(a, sample_positions(1000, 10, 42));
+        assert_ne!(a, sample_positions(1000, 10, 43));
+        assert_eq!(a.len(), 10);
+        assert!(a.windows(2).all(|w| w[0] < w[1]));
+        assert_eq!(sample_positions(3, 10, 1), vec![0, 1, 2]);
+    }
+}
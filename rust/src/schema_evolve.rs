@@ -10,19 +10,21 @@
 //! come back through scalar out-pointers.
 //!
 //! Scope (v1):
-//!   - add_columns: NewColumnTransform::SqlExpressions only.
-//!     BatchUDF / Stream / Reader / AllNulls are not exposed because
-//!     they require either Rust closures or full Arrow IPC plumbing.
+//!   - add_columns: NewColumnTransform::SqlExpressions, plus AllNulls
+//!     through add_null_columns (the schema arrives as Arrow IPC).
+//!     BatchUDF / Stream / Reader are not exposed because they require
+//!     Rust closures; see backfill.rs for Go-computed columns.
 //!     SqlExpressions covers the common "derive from existing columns"
 //!     case (e.g. `score * 2`, `date_trunc('day', ts)`) and is the only
 //!     transform reachable from Python's `add_columns(transforms=dict)`.
-//!   - alter_columns: rename + nullable only. The data_type cast
-//!     variant is gated behind Arrow DataType serialization and is
-//!     deliberately deferred to a follow-up PR.
+//!   - alter_columns: rename + nullable, plus data_type casts via
+//!     alter_columns_v2, which takes the target types as an Arrow IPC
+//!     schema alongside the JSON alteration list.
 //!   - drop_columns: full surface — just a list of column names.
 
 use crate::ffi::{from_c_str, SimpleResult};
 use crate::runtime::get_simple_runtime;
+use arrow_schema::SchemaRef;
 use lancedb::table::{ColumnAlteration, NewColumnTransform};
 use serde::Deserialize;
 use std::os::raw::{c_char, c_void};
@@ -113,85 +115,87 @@ pub extern "C" fn simple_lancedb_table_add_columns(
     }
 }
 
-/// Alter existing columns — rename and/or change nullability.
-/// `alterations_json` is a JSON array of {"path": "<col>", "rename":
-/// "<new>", "nullable": <bool>} objects; rename and nullable are both
-/// optional and "unset" means "leave that attribute alone". Empty
-/// arrays are rejected.
-///
-/// data_type casts are intentionally not exposed in v1 — they require
-/// Arrow DataType serialization and are slated for a follow-up. Callers
-/// who need a cast can drop and re-add the column through add_columns.
+/// Add all-null columns described by the fields of the Arrow IPC schema
+/// in `schema_ipc`. Only the manifest changes — no data files are
+/// written — so any Arrow type works, including FixedSizeList vectors
+/// and structs. Every field must be nullable.
 ///
 /// On success, the new commit version is written to *version_out.
 #[no_mangle]
 #[allow(clippy::not_unsafe_ptr_arg_deref)]
-pub extern "C" fn simple_lancedb_table_alter_columns(
+pub extern "C" fn simple_lancedb_table_add_null_columns(
     table_handle: *mut c_void,
-    alterations_json: *const c_char,
+    schema_ipc: *const u8,
+    schema_len: usize,
     version_out: *mut u64,
 ) -> *mut SimpleResult {
     let result = std::panic::catch_unwind(|| -> SimpleResult {
-        if table_handle.is_null() || alterations_json.is_null() || version_out.is_null() {
+        if table_handle.is_null() || schema_ipc.is_null() || version_out.is_null() {
             return SimpleResult::error("Invalid null arguments".to_string());
         }
-        let json = match from_c_str(alterations_json) {
-            Ok(s) => s,
-            Err(e) => return SimpleResult::error(format!("Invalid alterations_json: {}", e)),
+        let bytes = unsafe { std::slice::from_raw_parts(schema_ipc, schema_len) };
+        let schema = match arrow_ipc::reader::FileReader::try_new(std::io::Cursor::new(bytes), None)
+        {
+            Ok(reader) => reader.schema(),
+            Err(e) => return SimpleResult::error(format!("Invalid IPC schema: {}", e)),
         };
-        let entries: Vec<AlterEntry> = match serde_json::from_str(&json) {
-            Ok(v) => v,
-            Err(e) => {
-                return SimpleResult::error(format!("Failed to parse alterations_json: {}", e))
-            }
-        };
-        if entries.is_empty() {
+        if schema.fields().is_empty() {
             return SimpleResult::error(
-                "alter_columns: alterations must be a non-empty array".to_string(),
+                "add_null_columns: schema must have at least one field".to_string(),
             );
         }
-        for (i, e) in entries.iter().enumerate() {
-            if e.path.trim().is_empty() {
-                return SimpleResult::error(format!(
-                    "alter_columns: alterations[{}].path is empty",
-                    i
-                ));
-            }
-            if e.rename.is_none() && e.nullable.is_none() {
-                return SimpleResult::error(format!(
-                    "alter_columns: alterations[{}] has no rename or nullable change",
-                    i
-                ));
-            }
+        if let Some(f) = schema.fields().iter().find(|f| !f.is_nullable()) {
+            return SimpleResult::error(format!(
+                "add_null_columns: field {} must be nullable",
+                f.name()
+            ));
         }
 
-        let alterations: Vec<ColumnAlteration> = entries
-            .into_iter()
-            .map(|e| {
-                let mut a = ColumnAlteration::new(e.path);
-                if let Some(new_name) = e.rename {
-                    a = a.rename(new_name);
-                }
-                if let Some(n) = e.nullable {
-                    a = a.set_nullable(n);
-                }
-                a
-            })
-            .collect();
-
         let table = unsafe { &*(table_handle as *const lancedb::Table) };
         let rt = get_simple_runtime();
-        match rt.block_on(async { table.alter_columns(&alterations).await }) {
+        match rt.block_on(async {
+            table
+                .add_columns(NewColumnTransform::AllNulls(schema), None)
+                .await
+        }) {
             Ok(res) => {
                 unsafe {
                     *version_out = res.version;
                 }
                 SimpleResult::ok()
             }
-            Err(e) => SimpleResult::error(format!("alter_columns failed: {}", e)),
+            Err(e) => SimpleResult::error(format!("add_null_columns failed: {}", e)),
         }
     });
 
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_add_null_columns".to_string(),
+        ))),
+    }
+}
+
+/// Alter existing columns — rename and/or change nullability.
+/// `alterations_json` is a JSON array of {"path": "<col>", "rename":
+/// "<new>", "nullable": <bool>} objects; rename and nullable are both
+/// optional and "unset" means "leave that attribute alone". Empty
+/// arrays are rejected.
+///
+/// Casts go through simple_lancedb_table_alter_columns_v2.
+///
+/// On success, the new commit version is written to *version_out.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_alter_columns(
+    table_handle: *mut c_void,
+    alterations_json: *const c_char,
+    version_out: *mut u64,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        alter_columns(table_handle, alterations_json, None, version_out)
+    });
+
     match result {
         Ok(res) => Box::into_raw(Box::new(res)),
         Err(_) => Box::into_raw(Box::new(SimpleResult::error(
@@ -200,6 +204,113 @@ pub extern "C" fn simple_lancedb_table_alter_columns(
     }
 }
 
+/// Like simple_lancedb_table_alter_columns, with optional data_type
+/// casts. `casts_ipc` is an Arrow IPC file whose schema holds one field
+/// per column to cast, named by the alteration path and typed with the
+/// target type; it may be null when no column is cast. Casts rewrite
+/// the affected column's data files. The caller validates that each
+/// cast is supported before calling.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_alter_columns_v2(
+    table_handle: *mut c_void,
+    alterations_json: *const c_char,
+    casts_ipc: *const u8,
+    casts_len: usize,
+    version_out: *mut u64,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        let casts = if casts_ipc.is_null() {
+            None
+        } else {
+            let bytes = unsafe { std::slice::from_raw_parts(casts_ipc, casts_len) };
+            match arrow_ipc::reader::FileReader::try_new(std::io::Cursor::new(bytes), None) {
+                Ok(reader) => Some(reader.schema()),
+                Err(e) => return SimpleResult::error(format!("Invalid casts IPC schema: {}", e)),
+            }
+        };
+        alter_columns(table_handle, alterations_json, casts, version_out)
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_alter_columns_v2".to_string(),
+        ))),
+    }
+}
+
+fn alter_columns(
+    table_handle: *mut c_void,
+    alterations_json: *const c_char,
+    casts: Option<SchemaRef>,
+    version_out: *mut u64,
+) -> SimpleResult {
+    if table_handle.is_null() || alterations_json.is_null() || version_out.is_null() {
+        return SimpleResult::error("Invalid null arguments".to_string());
+    }
+    let json = match from_c_str(alterations_json) {
+        Ok(s) => s,
+        Err(e) => return SimpleResult::error(format!("Invalid alterations_json: {}", e)),
+    };
+    let entries: Vec<AlterEntry> = match serde_json::from_str(&json) {
+        Ok(v) => v,
+        Err(e) => return SimpleResult::error(format!("Failed to parse alterations_json: {}", e)),
+    };
+    if entries.is_empty() {
+        return SimpleResult::error(
+            "alter_columns: alterations must be a non-empty array".to_string(),
+        );
+    }
+    let cast_to = |path: &str| {
+        casts
+            .as_ref()
+            .and_then(|s| s.field_with_name(path).ok())
+            .map(|f| f.data_type().clone())
+    };
+    for (i, e) in entries.iter().enumerate() {
+        if e.path.trim().is_empty() {
+            return SimpleResult::error(format!("alter_columns: alterations[{}].path is empty", i));
+        }
+        if e.rename.is_none() && e.nullable.is_none() && cast_to(&e.path).is_none() {
+            return SimpleResult::error(format!(
+                "alter_columns: alterations[{}] has no rename, nullable or data_type change",
+                i
+            ));
+        }
+    }
+
+    let alterations: Vec<ColumnAlteration> = entries
+        .into_iter()
+        .map(|e| {
+            let data_type = cast_to(&e.path);
+            let mut a = ColumnAlteration::new(e.path);
+            if let Some(new_name) = e.rename {
+                a = a.rename(new_name);
+            }
+            if let Some(n) = e.nullable {
+                a = a.set_nullable(n);
+            }
+            if let Some(dt) = data_type {
+                a = a.cast_to(dt);
+            }
+            a
+        })
+        .collect();
+
+    let table = unsafe { &*(table_handle as *const lancedb::Table) };
+    let rt = get_simple_runtime();
+    match rt.block_on(async { table.alter_columns(&alterations).await }) {
+        Ok(res) => {
+            unsafe {
+                *version_out = res.version;
+            }
+            SimpleResult::ok()
+        }
+        Err(e) => SimpleResult::error(format!("alter_columns failed: {}", e)),
+    }
+}
+
 /// Drop columns from the table. `columns_json` is a JSON array of
 /// strings naming the columns to remove. Empty arrays are rejected.
 ///
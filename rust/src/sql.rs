@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! SQL over the tables of a connection, executed by DataFusion.
+//!
+//! Each query gets a fresh SessionContext whose default schema resolves
+//! table names against the connection lazily: only the tables a query
+//! references are opened, each as a lance table provider so filters
+//! and projections are pushed into the scan. Vector distance UDFs are
+//! registered for similarity expressions in plain SQL. Results are
+//! returned as a stream handle (see the stream module).
+
+use crate::dataset::open_native_dataset;
+use crate::ffi::{from_c_str, SimpleResult};
+use crate::runtime::get_simple_runtime;
+use crate::stream::new_stream_handle;
+use arrow_array::cast::AsArray;
+use arrow_array::types::Float32Type;
+use arrow_array::{Array, ArrayRef, Float32Array};
+use arrow_schema::DataType;
+use async_trait::async_trait;
+use datafusion::catalog::{SchemaProvider, TableProvider};
+use datafusion::error::{DataFusionError, Result as DFResult};
+use datafusion::logical_expr::{
+    ColumnarValue, ScalarFunctionArgs, ScalarUDF, ScalarUDFImpl, Signature, Volatility,
+};
+use datafusion::prelude::SessionContext;
+use lance::datafusion::LanceTableProvider;
+use std::any::Any;
+use std::os::raw::{c_char, c_void};
+use std::sync::Arc;
+use tokio_stream::StreamExt;
+
+/// Resolves table names in SQL to the connection's tables.
+struct ConnectionSchemaProvider {
+    conn: lancedb::Connection,
+    names: Vec<String>,
+}
+
+impl std::fmt::Debug for ConnectionSchemaProvider {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.debug_struct("ConnectionSchemaProvider")
+            .field("names", &self.names)
+            .finish()
+    }
+}
+
+#[async_trait]
+impl SchemaProvider for ConnectionSchemaProvider {
+    fn as_any(&self) -> &dyn Any {
+        self
+    }
+
+    fn table_names(&self) -> Vec<String> {
+        self.names.clone()
+    }
+
+    async fn table(&self, name: &str) -> DFResult<Option<Arc<dyn TableProvider>>> {
+        if !self.table_exist(name) {
+            return Ok(None);
+        }
+        let table = self
+            .conn
+            .open_table(name)
+            .execute()
+            .await
+            .map_err(|e| DataFusionError::External(Box::new(e)))?;
+        let dataset = open_native_dataset(&table)
+            .await
+            .map_err(DataFusionError::Execution)?;
+        Ok(Some(Arc::new(LanceTableProvider::new(
+            Arc::new(dataset),
+            false,
+            false,
+        ))))
+    }
+
+    fn table_exist(&self, name: &str) -> bool {
+        self.names.iter().any(|n| n == name)
+    }
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]
+enum VectorFunction {
+    L2,
+    Cosine,
+    Dot,
+}
+
+impl VectorFunction {
+    fn eval(self, a: &[f32], b: &[f32]) -> f32 {
+        match self {
+            // Squared L2, matching the _distance of an L2 vector search.
+            VectorFunction::L2 => a.iter().zip(b).map(|(x, y)| (x - y) * (x - y)).sum(),
+            VectorFunction::Cosine => {
+                let dot: f32 = a.iter().zip(b).map(|(x, y)| x * y).sum();
+                let na: f32 = a.iter().map(|x| x * x).sum::<f32>().sqrt();
+                let nb: f32 = b.iter().map(|x| x * x).sum::<f32>().sqrt();
+                if na == 0.0 || nb == 0.0 {
+                    1.0
+                } else {
+                    1.0 - dot / (na * nb)
+                }
+            }
+            VectorFunction::Dot => a.iter().zip(b).map(|(x, y)| x * y).sum(),
+        }
+    }
+}
+
+/// A two-argument vector function over list or fixed-size-list values
+/// of any numeric type, computed in f32. Either argument may be a
+/// literal such as `[0.1, 0.2]`. Null vectors give a null result.
+#[derive(Debug, PartialEq, Eq, Hash)]
+struct VectorUdf {
+    name: &'static str,
+    function: VectorFunction,
+    signature: Signature,
+}
+
+impl VectorUdf {
+    fn new(name: &'static str, function: VectorFunction) -> ScalarUDF {
+        ScalarUDF::new_from_impl(Self {
+            name,
+            function,
+            signature: Signature::any(2, Volatility::Immutable),
+        })
+    }
+}
+
+/// Row i of a vector argument as f32 values, or None when null.
+fn vector_at(name: &str, array: &ArrayRef, i: usize) -> DFResult<Option<Vec<f32>>> {
+    if array.is_null(i) {
+        return Ok(None);
+    }
+    let values = match array.data_type() {
+        DataType::FixedSizeList(_, _) => array.as_fixed_size_list().value(i),
+        DataType::List(_) => array.as_list::<i32>().value(i),
+        DataType::LargeList(_) => array.as_list::<i64>().value(i),
+        other => {
+            return Err(DataFusionError::Execution(format!(
+                "{} expects vector arguments, got {}",
+                name, other
+            )))
+        }
+    };
+    let values = arrow_cast::cast(&values, &DataType::Float32)?;
+    Ok(Some(values.as_primitive::<Float32Type>().values().to_vec()))
+}
+
+impl ScalarUDFImpl for VectorUdf {
+    fn as_any(&self) -> &dyn Any {
+        self
+    }
+
+    fn name(&self) -> &str {
+        self.name
+    }
+
+    fn signature(&self) -> &Signature {
+        &self.signature
+    }
+
+    fn return_type(&self, _arg_types: &[DataType]) -> DFResult<DataType> {
+        Ok(DataType::Float32)
+    }
+
+    fn invoke_with_args(&self, args: ScalarFunctionArgs) -> DFResult<ColumnarValue> {
+        let arrays = ColumnarValue::values_to_arrays(&args.args)?;
+        let (left, right) = (&arrays[0], &arrays[1]);
+        let mut out = Vec::with_capacity(left.len());
+        for i in 0..left.len() {
+            match (
+                vector_at(self.name, left, i)?,
+                vector_at(self.name, right, i)?,
+            ) {
+                (Some(a), Some(b)) => {
+                    if a.len() != b.len() {
+                        return Err(DataFusionError::Execution(format!(
+                            "{}: vector lengths differ ({} and {})",
+                            self.name,
+                            a.len(),
+                            b.len()
+                        )));
+                    }
+                    out.push(Some(self.function.eval(&a, &b)));
+                }
+                _ => out.push(None),
+            }
+        }
+        Ok(ColumnarValue::Array(Arc::new(Float32Array::from(out))))
+    }
+}
+
+/// Build the session for one query against `conn`.
+async fn session_for(conn: &lancedb::Connection) -> Result<SessionContext, String> {
+    let names = conn
+        .table_names()
+        .execute()
+        .await
+        .map_err(|e| e.to_string())?;
+    let ctx = SessionContext::new();
+    let catalog = ctx
+        .catalog("datafusion")
+        .ok_or_else(|| "default catalog missing".to_string())?;
+    catalog
+        .register_schema(
+            "public",
+            Arc::new(ConnectionSchemaProvider {
+                conn: conn.clone(),
+                names,
+            }),
+        )
+        .map_err(|e| e.to_string())?;
+    ctx.register_udf(VectorUdf::new("l2_distance", VectorFunction::L2));
+    ctx.register_udf(VectorUdf::new("cosine_distance", VectorFunction::Cosine));
+    ctx.register_udf(VectorUdf::new("dot_product", VectorFunction::Dot));
+    Ok(ctx)
+}
+
+/// Plan and start a SQL query over the connection's tables. Table
+/// names resolve to the latest version of each table. Besides
+/// DataFusion's built-in functions, `l2_distance(a, b)` (squared),
+/// `cosine_distance(a, b)` and `dot_product(a, b)` are available for
+/// vector columns and list literals. On success *stream_handle is set
+/// to a stream read with simple_lancedb_stream_next and released with
+/// simple_lancedb_stream_close.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_sql(
+    handle: *mut c_void,
+    sql: *const c_char,
+    stream_handle: *mut *mut c_void,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if handle.is_null() || sql.is_null() || stream_handle.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let sql = match from_c_str(sql) {
+            Ok(s) => s,
+            Err(e) => return SimpleResult::error(format!("Invalid SQL: {}", e)),
+        };
+
+        let conn = unsafe { &*(handle as *const lancedb::Connection) };
+        let rt = get_simple_runtime();
+        match rt.block_on(async {
+            let ctx = session_for(conn).await?;
+            let df = ctx.sql(&sql).await.map_err(|e| e.to_string())?;
+            let stream = df.execute_stream().await.map_err(|e| e.to_string())?;
+            Ok::<_, String>(stream)
+        }) {
+            Ok(stream) => {
+                let schema = stream.schema();
+                let stream = Box::pin(stream.map(|r| r.map_err(|e| e.to_string())));
+                unsafe {
+                    *stream_handle = new_stream_handle(schema, stream);
+                }
+                SimpleResult::ok()
+            }
+            Err(e) => SimpleResult::error(format!("Failed to execute SQL: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_sql".to_string(),
+        ))),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn vector_functions() {
+        let a = [1.0, 0.0];
+        let b = [0.0, 2.0];
+        assert_eq!(VectorFunction::L2.eval(&a, &b), 5.0);
+        assert_eq!(VectorFunction::Dot.eval(&a, &b), 0.0);
+        assert_eq!(VectorFunction::Cosine.eval(&a, &b), 1.0);
+        assert_eq!(VectorFunction::Cosine.eval(&a, &a), 0.0);
+        assert_eq!(VectorFunction::Cosine.eval(&a, &[0.0, 0.0]), 1.0);
+    }
+}
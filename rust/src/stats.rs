@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Storage statistics for a table: bytes on disk, fragment layout,
+//! deletions and index sizes, gathered from the backing lance dataset
+//! so maintenance jobs can decide when compaction is worth running.
+
+use crate::dataset::open_native_dataset;
+use crate::ffi::SimpleResult;
+use crate::runtime::get_simple_runtime;
+use serde::Serialize;
+use std::collections::BTreeMap;
+use std::ffi::CString;
+use std::os::raw::{c_char, c_void};
+use tokio_stream::StreamExt;
+
+#[derive(Serialize)]
+struct FragmentRowStats {
+    min: u64,
+    max: u64,
+    p50: u64,
+}
+
+#[derive(Serialize)]
+struct IndexSize {
+    name: String,
+    num_segments: u64,
+    size_bytes: u64,
+}
+
+#[derive(Serialize)]
+struct TableStats {
+    version: u64,
+    total_bytes: u64,
+    num_rows: u64,
+    num_deleted_rows: u64,
+    num_fragments: u64,
+    num_fragments_with_deletions: u64,
+    rows_per_fragment: FragmentRowStats,
+    num_data_files: u64,
+    num_deletion_files: u64,
+    indices: Vec<IndexSize>,
+}
+
+/// Nearest-rank percentile of an ascending slice.
+fn percentile(sorted: &[u64], p: f64) -> u64 {
+    if sorted.is_empty() {
+        return 0;
+    }
+    let rank = ((p * sorted.len() as f64).ceil() as usize).clamp(1, sorted.len());
+    sorted[rank - 1]
+}
+
+async fn collect_stats(table: &lancedb::Table) -> Result<TableStats, String> {
+    let dataset = open_native_dataset(table).await?;
+
+    let total_bytes = dataset
+        .calculate_data_stats()
+        .await
+        .map_err(|e| e.to_string())?
+        .fields
+        .iter()
+        .map(|f| f.bytes_on_disk)
+        .sum();
+
+    let fragments = dataset.get_fragments();
+    let mut live_rows = Vec::with_capacity(fragments.len());
+    let mut num_deleted_rows = 0u64;
+    let mut num_fragments_with_deletions = 0u64;
+    let mut num_data_files = 0u64;
+    let mut num_deletion_files = 0u64;
+    for fragment in &fragments {
+        let physical = fragment.physical_rows().await.map_err(|e| e.to_string())? as u64;
+        let deleted = fragment
+            .count_deletions()
+            .await
+            .map_err(|e| e.to_string())? as u64;
+        live_rows.push(physical.saturating_sub(deleted));
+        num_deleted_rows += deleted;
+        if deleted > 0 {
+            num_fragments_with_deletions += 1;
+        }
+        let meta = fragment.metadata();
+        num_data_files += meta.files.len() as u64;
+        if meta.deletion_file.is_some() {
+            num_deletion_files += 1;
+        }
+    }
+    live_rows.sort_unstable();
+    let rows_per_fragment = FragmentRowStats {
+        min: live_rows.first().copied().unwrap_or(0),
+        max: live_rows.last().copied().unwrap_or(0),
+        p50: percentile(&live_rows, 0.5),
+    };
+
+    // An index is one or more segments (delta indices after appends),
+    // each stored under its own UUID directory.
+    let mut indices: BTreeMap<String, IndexSize> = BTreeMap::new();
+    let index_metas = dataset.load_indices().await.map_err(|e| e.to_string())?;
+    for meta in index_metas.iter() {
+        let dir = dataset.indices_dir().child(meta.uuid.to_string());
+        let mut size = 0u64;
+        let mut files = dataset.object_store().read_dir_all(&dir, None);
+        while let Some(obj) = files.next().await {
+            size += obj.map_err(|e| e.to_string())?.size as u64;
+        }
+        let entry = indices
+            .entry(meta.name.clone())
+            .or_insert_with(|| IndexSize {
+                name: meta.name.clone(),
+                num_segments: 0,
+                size_bytes: 0,
+            });
+        entry.num_segments += 1;
+        entry.size_bytes += size;
+    }
+
+    Ok(TableStats {
+        version: dataset.version().version,
+        total_bytes,
+        num_rows: live_rows.iter().sum(),
+        num_deleted_rows,
+        num_fragments: fragments.len() as u64,
+        num_fragments_with_deletions,
+        rows_per_fragment,
+        num_data_files,
+        num_deletion_files,
+        indices: indices.into_values().collect(),
+    })
+}
+
+/// Compute storage statistics for the version the table handle sees.
+/// The result is a JSON object written to *stats_json and freed with
+/// simple_lancedb_free_string.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_stats(
+    table_handle: *mut c_void,
+    stats_json: *mut *mut c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if table_handle.is_null() || stats_json.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+
+        let table = unsafe { &*(table_handle as *const lancedb::Table) };
+        let rt = get_simple_runtime();
+
+        match rt.block_on(collect_stats(table)) {
+            Ok(stats) => {
+                let json = match serde_json::to_string(&stats) {
+                    Ok(s) => s,
+                    Err(e) => {
+                        return SimpleResult::error(format!("Failed to serialize stats: {}", e))
+                    }
+                };
+                match CString::new(json) {
+                    Ok(c) => {
+                        unsafe {
+                            *stats_json = c.into_raw();
+                        }
+                        SimpleResult::ok()
+                    }
+                    Err(_) => SimpleResult::error("Failed to convert JSON to C string".to_string()),
+                }
+            }
+            Err(e) => SimpleResult::error(format!("Failed to compute table stats: {}", e)),
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_stats".to_string(),
+        ))),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::percentile;
+
+    #[test]
+    fn percentile_nearest_rank() {
+        assert_eq!(percentile(&[], 0.5), 0);
+        assert_eq!(percentile(&[7], 0.5), 7);
+        assert_eq!(percentile(&[1, 2, 3, 4], 0.5), 2);
+        assert_eq!(percentile(&[1, 2, 3, 4, 5], 0.5), 3);
+    }
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Record batch streams handed to Go one batch at a time.
+//!
+//! A stream handle owns an async stream of record batches and its
+//! schema. Go pulls batches with simple_lancedb_stream_next, each
+//! encoded as its own Arrow IPC file, so a large result never has to be
+//! materialized in full on either side of the FFI boundary. Calls on a
+//! single handle must not overlap.
+
+use crate::ffi::SimpleResult;
+use crate::query::write_ipc_result;
+use crate::runtime::get_simple_runtime;
+use arrow_array::RecordBatch;
+use arrow_schema::SchemaRef;
+use std::os::raw::c_void;
+use std::pin::Pin;
+use tokio_stream::{Stream, StreamExt};
+
+/// The batch stream type behind a stream handle. Errors are already
+/// rendered as strings so streams from lancedb and DataFusion fit.
+pub(crate) type BatchStream = Pin<Box<dyn Stream<Item = Result<RecordBatch, String>> + Send>>;
+
+pub(crate) struct RecordBatchStreamHandle {
+    schema: SchemaRef,
+    stream: BatchStream,
+}
+
+/// Box a stream into an opaque handle for C. The handle is released
+/// with simple_lancedb_stream_close.
+pub(crate) fn new_stream_handle(schema: SchemaRef, stream: BatchStream) -> *mut c_void {
+    Box::into_raw(Box::new(RecordBatchStreamHandle { schema, stream })) as *mut c_void
+}
+
+/// Write the schema of the stream as an Arrow IPC file with no record
+/// batches. The buffer is freed with simple_lancedb_free_ipc_data.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_stream_schema(
+    stream_handle: *mut c_void,
+    schema_ipc_data: *mut *mut u8,
+    schema_ipc_len: *mut usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if stream_handle.is_null() || schema_ipc_data.is_null() || schema_ipc_len.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let handle = unsafe { &*(stream_handle as *const RecordBatchStreamHandle) };
+        write_ipc_result(&handle.schema, &[], schema_ipc_data, schema_ipc_len)
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_stream_schema".to_string(),
+        ))),
+    }
+}
+
+/// Pull the next record batch from the stream as an Arrow IPC file.
+/// At the end of the stream *batch_ipc_data is set to NULL and
+/// *batch_ipc_len to 0. The buffer is freed with
+/// simple_lancedb_free_ipc_data.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_stream_next(
+    stream_handle: *mut c_void,
+    batch_ipc_data: *mut *mut u8,
+    batch_ipc_len: *mut usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        if stream_handle.is_null() || batch_ipc_data.is_null() || batch_ipc_len.is_null() {
+            return SimpleResult::error("Invalid null arguments".to_string());
+        }
+        let handle = unsafe { &mut *(stream_handle as *mut RecordBatchStreamHandle) };
+        let rt = get_simple_runtime();
+
+        // Skip empty batches so Go never sees a zero-row record.
+        let next = rt.block_on(async {
+            while let Some(batch) = handle.stream.next().await {
+                match batch {
+                    Ok(b) if b.num_rows() == 0 => continue,
+                    other => return Some(other),
+                }
+            }
+            None
+        });
+        match next {
+            Some(Ok(batch)) => {
+                write_ipc_result(&handle.schema, &[batch], batch_ipc_data, batch_ipc_len)
+            }
+            Some(Err(e)) => SimpleResult::error(format!("Failed to read stream: {}", e)),
+            None => {
+                unsafe {
+                    *batch_ipc_data = std::ptr::null_mut();
+                    *batch_ipc_len = 0;
+                }
+                SimpleResult::ok()
+            }
+        }
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_stream_next".to_string(),
+        ))),
+    }
+}
+
+/// Close a stream handle, dropping any batches not yet read.
+#[no_mangle]
+pub extern "C" fn simple_lancedb_stream_close(stream_handle: *mut c_void) -> *mut SimpleResult {
+    if stream_handle.is_null() {
+        return Box::into_raw(Box::new(SimpleResult::error(
+            "Invalid null handle".to_string(),
+        )));
+    }
+
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        unsafe {
+            let _stream = Box::from_raw(stream_handle as *mut RecordBatchStreamHandle);
+        }
+        SimpleResult::ok()
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_stream_close".to_string(),
+        ))),
+    }
+}
@@ -7,7 +7,9 @@ use crate::ffi::{from_c_str, SimpleResult};
 use crate::runtime::get_simple_runtime;
 use crate::schema::create_arrow_schema_from_json;
 use chrono::TimeDelta;
-use lancedb::table::{CompactionOptions, OptimizeAction, OptimizeOptions};
+use lance::dataset::WriteParams;
+use lancedb::table::{CompactionOptions, OptimizeAction, OptimizeOptions, WriteOptions};
+use serde::Deserialize;
 use std::ffi::CString;
 use std::os::raw::{c_char, c_void};
 use std::sync::Arc;
@@ -68,51 +70,83 @@ pub extern "C" fn simple_lancedb_create_table(
     }
 }
 
-/// Create a table with Arrow IPC schema (more efficient than JSON)
-#[no_mangle]
-#[allow(clippy::not_unsafe_ptr_arg_deref)]
-pub extern "C" fn simple_lancedb_create_table_with_ipc(
+/// Options accepted by simple_lancedb_create_table_with_ipc_v2. Every
+/// field defaults to lancedb's own default when omitted.
+#[derive(Debug, Default, Deserialize)]
+struct CreateTableOptions {
+    #[serde(default)]
+    enable_stable_row_ids: bool,
+}
+
+/// Shared body of the IPC create-table entry points.
+fn create_table_from_ipc(
     handle: *mut c_void,
     table_name: *const c_char,
     schema_ipc: *const u8,
     schema_len: usize,
-) -> *mut SimpleResult {
-    let result = std::panic::catch_unwind(|| -> SimpleResult {
-        if handle.is_null() || table_name.is_null() || schema_ipc.is_null() {
-            return SimpleResult::error("Invalid null arguments".to_string());
-        }
+    options: CreateTableOptions,
+) -> SimpleResult {
+    if handle.is_null() || table_name.is_null() || schema_ipc.is_null() {
+        return SimpleResult::error("Invalid null arguments".to_string());
+    }
 
-        let name = match from_c_str(table_name) {
-            Ok(s) => s,
-            Err(e) => return SimpleResult::error(format!("Invalid table name: {}", e)),
-        };
+    let name = match from_c_str(table_name) {
+        Ok(s) => s,
+        Err(e) => return SimpleResult::error(format!("Invalid table name: {}", e)),
+    };
 
-        // Convert raw pointer to slice
-        let schema_bytes = unsafe { std::slice::from_raw_parts(schema_ipc, schema_len) };
+    // Convert raw pointer to slice
+    let schema_bytes = unsafe { std::slice::from_raw_parts(schema_ipc, schema_len) };
 
-        let conn = unsafe { &*(handle as *const lancedb::Connection) };
-        let rt = get_simple_runtime();
+    let conn = unsafe { &*(handle as *const lancedb::Connection) };
+    let rt = get_simple_runtime();
 
-        // Deserialize Arrow schema directly from IPC bytes using FileReader
-        let arrow_schema = match arrow_ipc::reader::FileReader::try_new(
-            std::io::Cursor::new(schema_bytes),
-            None,
-        ) {
+    // Deserialize Arrow schema directly from IPC bytes using FileReader
+    let arrow_schema =
+        match arrow_ipc::reader::FileReader::try_new(std::io::Cursor::new(schema_bytes), None) {
             Ok(reader) => reader.schema(),
             Err(e) => return SimpleResult::error(format!("Invalid IPC schema: {}", e)),
         };
 
-        match rt.block_on(async {
-            use arrow_array::RecordBatchIterator;
-            let empty_batches = RecordBatchIterator::new(
-                vec![] as Vec<Result<arrow_array::RecordBatch, arrow_schema::ArrowError>>,
-                arrow_schema, // arrow_schema is already Arc<Schema>
-            );
-            conn.create_table(&name, empty_batches).execute().await
-        }) {
-            Ok(_) => SimpleResult::ok(),
-            Err(e) => SimpleResult::error(format!("Failed to create table: {}", e)),
+    match rt.block_on(async {
+        use arrow_array::RecordBatchIterator;
+        let empty_batches = RecordBatchIterator::new(
+            vec![] as Vec<Result<arrow_array::RecordBatch, arrow_schema::ArrowError>>,
+            arrow_schema, // arrow_schema is already Arc<Schema>
+        );
+        let mut builder = conn.create_table(&name, empty_batches);
+        if options.enable_stable_row_ids {
+            builder = builder.write_options(WriteOptions {
+                lance_write_params: Some(WriteParams {
+                    enable_stable_row_ids: true,
+                    ..Default::default()
+                }),
+            });
         }
+        builder.execute().await
+    }) {
+        Ok(_) => SimpleResult::ok(),
+        Err(e) => SimpleResult::error(format!("Failed to create table: {}", e)),
+    }
+}
+
+/// Create a table with Arrow IPC schema (more efficient than JSON)
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_create_table_with_ipc(
+    handle: *mut c_void,
+    table_name: *const c_char,
+    schema_ipc: *const u8,
+    schema_len: usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        create_table_from_ipc(
+            handle,
+            table_name,
+            schema_ipc,
+            schema_len,
+            CreateTableOptions::default(),
+        )
     });
 
     match result {
@@ -123,6 +157,44 @@ pub extern "C" fn simple_lancedb_create_table_with_ipc(
     }
 }
 
+/// Create a table with Arrow IPC schema and creation options.
+/// `options_json` is a JSON object such as `{"enable_stable_row_ids": true}`;
+/// null selects the defaults, matching simple_lancedb_create_table_with_ipc.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_create_table_with_ipc_v2(
+    handle: *mut c_void,
+    table_name: *const c_char,
+    schema_ipc: *const u8,
+    schema_len: usize,
+    options_json: *const c_char,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        let options = if options_json.is_null() {
+            CreateTableOptions::default()
+        } else {
+            let json = match from_c_str(options_json) {
+                Ok(s) => s,
+                Err(e) => return SimpleResult::error(format!("Invalid options JSON: {}", e)),
+            };
+            match serde_json::from_str::<CreateTableOptions>(&json) {
+                Ok(o) => o,
+                Err(e) => {
+                    return SimpleResult::error(format!("Failed to parse create options: {}", e))
+                }
+            }
+        };
+        create_table_from_ipc(handle, table_name, schema_ipc, schema_len, options)
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_create_table_with_ipc_v2".to_string(),
+        ))),
+    }
+}
+
 /// Drop a table from the database (simple version)
 #[no_mangle]
 pub extern "C" fn simple_lancedb_drop_table(
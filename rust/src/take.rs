@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: Copyright The LanceDB Authors
+
+//! Random access by row ID or row offset.
+//!
+//! These entry points read the requested rows straight from the native
+//! dataset with Dataset::take_rows / Dataset::take. They never build a
+//! scan plan or filter, which is what makes hydrating the hits of a
+//! vector search (fetch `_rowid`s first, heavy columns second) cheap.
+
+use crate::dataset::open_native_dataset;
+use crate::ffi::{from_c_str, SimpleResult};
+use crate::query::write_ipc_result;
+use crate::runtime::get_simple_runtime;
+use lance::dataset::ProjectionRequest;
+use std::os::raw::{c_char, c_void};
+
+#[derive(Clone, Copy)]
+enum TakeBy {
+    RowIds,
+    Offsets,
+}
+
+/// Parse the optional JSON column list. Null or an empty array selects
+/// every column of the table.
+pub(crate) fn parse_columns(columns_json: *const c_char) -> Result<Vec<String>, String> {
+    if columns_json.is_null() {
+        return Ok(Vec::new());
+    }
+    let s = from_c_str(columns_json).map_err(|e| e.to_string())?;
+    serde_json::from_str(&s).map_err(|e| e.to_string())
+}
+
+/// Shared body of the take entry points.
+fn take_to_ipc(
+    table_handle: *mut c_void,
+    by: TakeBy,
+    ids: *const u64,
+    ids_len: usize,
+    columns_json: *const c_char,
+    result_ipc_data: *mut *mut u8,
+    result_ipc_len: *mut usize,
+) -> SimpleResult {
+    if table_handle.is_null()
+        || (ids.is_null() && ids_len > 0)
+        || result_ipc_data.is_null()
+        || result_ipc_len.is_null()
+    {
+        return SimpleResult::error("Invalid null arguments".to_string());
+    }
+    let columns = match parse_columns(columns_json) {
+        Ok(c) => c,
+        Err(e) => return SimpleResult::error(format!("Invalid columns_json: {}", e)),
+    };
+    let ids: &[u64] = if ids_len == 0 {
+        &[]
+    } else {
+        unsafe { std::slice::from_raw_parts(ids, ids_len) }
+    };
+
+    let table = unsafe { &*(table_handle as *const lancedb::Table) };
+    let rt = get_simple_runtime();
+    match rt.block_on(async {
+        let dataset = open_native_dataset(table).await?;
+        let projection = if columns.is_empty() {
+            dataset.schema().clone()
+        } else {
+            dataset
+                .schema()
+                .project(&columns)
+                .map_err(|e| e.to_string())?
+        };
+        let projection = ProjectionRequest::from_schema(projection);
+        let batch = match by {
+            TakeBy::RowIds => dataset.take_rows(ids, projection).await,
+            TakeBy::Offsets => dataset.take(ids, projection).await,
+        }
+        .map_err(|e| e.to_string())?;
+        Ok::<_, String>(batch)
+    }) {
+        Ok(batch) => {
+            let schema = batch.schema();
+            write_ipc_result(&schema, &[batch], result_ipc_data, result_ipc_len)
+        }
+        Err(e) => SimpleResult::error(format!("Failed to take rows: {}", e)),
+    }
+}
+
+/// Fetch rows by row ID (the `_rowid` column of a query with
+/// with_row_id) and return them as an Arrow IPC file, in the order of
+/// `row_ids`. `columns_json` is a JSON array of column names, or null
+/// for every column. The IPC buffer is freed with
+/// simple_lancedb_free_ipc_data.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_take_row_ids(
+    table_handle: *mut c_void,
+    row_ids: *const u64,
+    row_ids_len: usize,
+    columns_json: *const c_char,
+    result_ipc_data: *mut *mut u8,
+    result_ipc_len: *mut usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        take_to_ipc(
+            table_handle,
+            TakeBy::RowIds,
+            row_ids,
+            row_ids_len,
+            columns_json,
+            result_ipc_data,
+            result_ipc_len,
+        )
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_take_row_ids".to_string(),
+        ))),
+    }
+}
+
+/// Fetch rows by their zero-based offset in the version the table
+/// handle sees and return them as an Arrow IPC file, in the order of
+/// `offsets`. Deleted rows do not count towards offsets. `columns_json`
+/// is a JSON array of column names, or null for every column. The IPC
+/// buffer is freed with simple_lancedb_free_ipc_data.
+#[no_mangle]
+#[allow(clippy::not_unsafe_ptr_arg_deref)]
+pub extern "C" fn simple_lancedb_table_take_offsets(
+    table_handle: *mut c_void,
+    offsets: *const u64,
+    offsets_len: usize,
+    columns_json: *const c_char,
+    result_ipc_data: *mut *mut u8,
+    result_ipc_len: *mut usize,
+) -> *mut SimpleResult {
+    let result = std::panic::catch_unwind(|| -> SimpleResult {
+        take_to_ipc(
+            table_handle,
+            TakeBy::Offsets,
+            offsets,
+            offsets_len,
+            columns_json,
+            result_ipc_data,
+            result_ipc_len,
+        )
+    });
+
+    match result {
+        Ok(res) => Box::into_raw(Box::new(res)),
+        Err(_) => Box::into_raw(Box::new(SimpleResult::error(
+            "Panic in simple_lancedb_table_take_offsets".to_string(),
+        ))),
+    }
+}